@@ -0,0 +1,197 @@
+// Command kalpindex generates the CouchDB index definitions declared by `kalp:"index=<name>"` struct tags.
+//
+// It reads the Go package in the given directory and writes one <name>.json file per index into the
+// META-INF/statedb/couchdb/indexes folder of the chaincode, where the peer picks them up when the chaincode is
+// installed. The fields of an index are taken in the order they are declared in the struct, under their JSON names.
+//
+// Usage, typically from a go:generate directive next to the asset types:
+//
+//	//go:generate go run github.com/p2eengineering/kalp-sdk-public/cmd/kalpindex -out META-INF/statedb/couchdb/indexes
+//
+// Flags:
+//
+//	-dir   the directory of the package declaring the asset types (default ".")
+//	-out   the directory the index files are written to (default "META-INF/statedb/couchdb/indexes")
+//	-type  a comma-separated list of the struct types to read (default all)
+package main
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
+)
+
+// defaultOut is the folder the peer reads CouchDB indexes from, relative to the chaincode root.
+const defaultOut = "META-INF/statedb/couchdb/indexes"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "kalpindex: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the flags, generates the index definitions and writes them.
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("kalpindex", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "the directory of the package declaring the asset types")
+	out := flags.String("out", defaultOut, "the directory the index files are written to")
+	types := flags.String("type", "", "a comma-separated list of the struct types to read (default all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var typeNames []string
+	if *types != "" {
+		typeNames = strings.Split(*types, ",")
+	}
+
+	indexes, err := generate(*dir, typeNames)
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		return fmt.Errorf("no %s index tags found in %s", query.TagName, *dir)
+	}
+	return write(*out, indexes, stdout)
+}
+
+// generate reads the index declarations of the struct types of the package in `dir`. If typeNames is not empty,
+// only the named types are read, and each of them must exist.
+func generate(dir string, typeNames []string) ([]query.Index, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", dir, err)
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range typeNames {
+		wanted[strings.TrimSpace(name)] = false
+	}
+
+	builder := query.NewIndexBuilder()
+	for _, file := range sortedFiles(pkgs) {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				if _, ok := wanted[typeSpec.Name.Name]; len(typeNames) > 0 && !ok {
+					continue
+				}
+				wanted[typeSpec.Name.Name] = true
+				if err := addStruct(builder, typeSpec.Name.Name, structType); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for name, found := range wanted {
+		if !found {
+			return nil, fmt.Errorf("struct type %s not found in %s", name, dir)
+		}
+	}
+	return builder.Indexes(), nil
+}
+
+// addStruct adds the tagged fields of a struct type to the builder.
+func addStruct(builder *query.IndexBuilder, typeName string, structType *ast.StructType) error {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tagValue, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return fmt.Errorf("invalid tag in %s: %v", typeName, err)
+		}
+		tag := reflect.StructTag(tagValue)
+
+		names := field.Names
+		if len(names) == 0 {
+			// An embedded field is encoded under the name of its type
+			names = []*ast.Ident{embeddedName(field.Type)}
+		}
+		for _, name := range names {
+			if err := builder.AddField(typeName, name.Name, tag.Get("json"), tag.Get(query.TagName)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedName returns the name of the type of an embedded field.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.Ident:
+		return t
+	}
+	return ast.NewIdent("")
+}
+
+// sortedFiles returns the files of the packages ordered by file name, so that the output does not depend on map
+// iteration.
+func sortedFiles(pkgs map[string]*ast.Package) []*ast.File {
+	var names []string
+	files := map[string]*ast.File{}
+	for _, pkg := range pkgs {
+		for name, file := range pkg.Files {
+			names = append(names, name)
+			files[name] = file
+		}
+	}
+	sort.Strings(names)
+
+	sorted := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, files[name])
+	}
+	return sorted
+}
+
+// write writes one file per index into `out`.
+func write(out string, indexes []query.Index, stdout io.Writer) error {
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", out, err)
+	}
+	for _, index := range indexes {
+		data, err := json.MarshalIndent(index, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal index %s: %v", index.Name, err)
+		}
+		path := filepath.Join(out, index.FileName())
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		fmt.Fprintf(stdout, "wrote %s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	//Standard Libs
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+const testSource = `package assets
+
+type NIU struct {
+	Id      string ` + "`json:\"id\" kalp:\"index=byIdDocType\"`" + `
+	DocType string ` + "`json:\"docType\" kalp:\"index=byIdDocType,index=byOwner\"`" + `
+	Owner   string ` + "`json:\"owner\" kalp:\"index=byOwner\"`" + `
+}
+
+type Payment struct {
+	Payer, Payee string ` + "`kalp:\"index=byPayer\"`" + `
+}
+`
+
+// writeTestPackage writes a package declaring tagged asset types and returns its directory.
+func writeTestPackage(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets.go"), []byte(testSource), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets_test.go"), []byte("package assets\n\ntype fixture struct {\n\tX string `kalp:\"bad\"`\n}\n"), 0o644))
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	dir := writeTestPackage(t)
+	out := filepath.Join(dir, "META-INF", "statedb", "couchdb", "indexes")
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.NoError(t, run([]string{"-dir", dir, "-out", out, "-type", "NIU"}, &stdout))
+		require.Contains(t, stdout.String(), "byOwner.json")
+
+		data, err := os.ReadFile(filepath.Join(out, "byIdDocType.json"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"index":{"fields":["id","docType"]},"ddoc":"byIdDocTypeDoc","name":"byIdDocType","type":"json"}`, string(data))
+
+		_, err = os.Stat(filepath.Join(out, "byPayer.json"))
+		require.True(t, os.IsNotExist(err))
+
+		require.NoError(t, run([]string{"-dir", dir, "-out", out}, &stdout))
+		data, err = os.ReadFile(filepath.Join(out, "byPayer.json"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"index":{"fields":["Payer","Payee"]},"ddoc":"byPayerDoc","name":"byPayer","type":"json"}`, string(data))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.EqualError(t, run([]string{"-dir", dir, "-out", out, "-type", "Token"}, &stdout), "struct type Token not found in "+dir)
+		empty := t.TempDir()
+		require.EqualError(t, run([]string{"-dir", empty, "-out", out}, &stdout), "no kalp index tags found in "+empty)
+	})
+}
@@ -10,8 +10,11 @@ import (
 
 func main() {
 
-	// Creating a sample payable contract object
-	contract := kalpsdk.Contract{IsPayableContract: true}
+	// Creating a sample contract object where minting an NIU is paid for; the queries must be listed to be free
+	contract := kalpsdk.Contract{
+		PayableFunctions:     map[string]kalpsdk.PayableFunction{"CreateNIU": {Currency: "INR"}},
+		EvaluateTransactions: []string{"ReadNIU", "ListNIUs", "ListNIUsByOwner"},
+	}
 
 	// Creating a KalpSDK Logger object
 	contract.Logger = kalpsdk.NewLogger()
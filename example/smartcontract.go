@@ -1,5 +1,7 @@
 package smartcontract
 
+//go:generate go run github.com/p2eengineering/kalp-sdk-public/cmd/kalpindex -type NIU -out META-INF/statedb/couchdb/indexes
+
 import (
 	//Standard Libs
 	"encoding/json"
@@ -9,13 +11,14 @@ import (
 
 	//Custom Build Libs
 	kalpsdk "github.com/p2eengineering/kalp-sdk-public/kalpsdk"
-	
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
 )
 
 const nameKey = "name"
 const symbolKey = "symbol"
 const statusInProgress = "INPROGRESS"
 const statusCompleted = "COMPLETED"
+const docTypeNIU = "NIU"
 
 // Smart Contract Object
 type SmartContract struct {
@@ -24,8 +27,8 @@ type SmartContract struct {
 
 // NIU Structure
 type NIU struct {
-	Id          string      `json:"id"`
-	DocType     string      `json:"docType"`
+	Id          string      `json:"id" kalp:"index=byIdDocType"`
+	DocType     string      `json:"docType" kalp:"index=byIdDocType"`
 	Name        string      `json:"name"`
 	Type        string      `json:"type"`
 	Desc        string      `json:"desc"`
@@ -37,6 +40,27 @@ type NIU struct {
 	AssetDigest string      `json:"assetDigest"`
 }
 
+// niuRepository returns the repository of the NIU assets, writing with KYC.
+// The assets are indexed by account and status, so that the assets of an owner can be found without a rich query.
+func niuRepository(sdk kalpsdk.TransactionContextInterface) *kalpsdk.Repository[NIU] {
+	return kalpsdk.NewRepository[NIU](sdk, docTypeNIU).WithIndex(niuAccountIndex)
+}
+
+// niuAccountIndex indexes the NIU assets under every account holding them, followed by their status
+var niuAccountIndex = kalpsdk.Index[NIU]{
+	Name: "account",
+	Keys: func(niu *NIU) [][]string {
+		keys := make([][]string, 0, len(niu.Account))
+		for _, account := range niu.Account {
+			keys = append(keys, []string{account, niu.Status})
+		}
+		return keys
+	},
+}
+
+// NIUPage is a page of NIU assets returned by ListNIUs
+type NIUPage kalpsdk.Page[NIU]
+
 // Initialize function initializes the smart contract by setting the name and symbol for the token.
 // It takes the transaction context interface and the token name and symbol as input parameters.
 // If the token name already exists in the state, the function returns an error indicating that the client is not authorized to change them.
@@ -98,7 +122,6 @@ func (s *SmartContract) CreateNIU(sdk kalpsdk.TransactionContextInterface, data
 		return fmt.Errorf("assetDigest can not be null")
 	}
 
-	
 	if niu.Status != statusInProgress && niu.Status != statusCompleted {
 		return fmt.Errorf("not a valid Status")
 	}
@@ -109,16 +132,10 @@ func (s *SmartContract) CreateNIU(sdk kalpsdk.TransactionContextInterface, data
 		return err
 	}
 
-	// Generate JSON representation of NIU struct.
-	niuJSON, err := json.Marshal(niu)
+	// Make sure the metadata can be marshalled.
+	_, err = json.Marshal(niu.MetaData)
 	if err != nil {
-		return fmt.Errorf("unable to Marshal Token struct : %v", err)
-	}
-
-	// Marshal metadata and put it in state.
-	metadataByte, err := json.Marshal(niu.MetaData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal the metadata: %v", err,metadataByte )
+		return fmt.Errorf("failed to marshal the metadata: %v", err)
 	}
 
 	// // Mint token and store the JSON representation in the state database.
@@ -128,7 +145,7 @@ func (s *SmartContract) CreateNIU(sdk kalpsdk.TransactionContextInterface, data
 	// }
 
 	// Store the NIU struct in the state database
-	if err := sdk.PutStateWithKYC(niu.Id, niuJSON); err != nil {
+	if err := niuRepository(sdk).Create(niu.Id, &niu); err != nil {
 		return fmt.Errorf("unable to put Asset struct in statedb: %v", err)
 	}
 
@@ -138,19 +155,9 @@ func (s *SmartContract) CreateNIU(sdk kalpsdk.TransactionContextInterface, data
 // ReadNIU retrieves the NIU asset with the given ID from the world state and returns it as a pointer to a NIU struct.
 func (s *SmartContract) ReadNIU(sdk kalpsdk.TransactionContextInterface, id string) (*NIU, error) {
 	// Get the asset from the ledger using id & check if asset exists
-	niuJSON, err := sdk.GetState(id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read from world state: %v", err)
-	}
-	if niuJSON == nil {
-		return nil, fmt.Errorf("the NIU asset with ID %v does not exist", id)
-	}
-
-	// Unmarshal asset from JSON to struct
-	var niu NIU
-	err = json.Unmarshal(niuJSON, &niu)
+	niu, err := niuRepository(sdk).MustGet(id)
 	if err != nil {
-		return nil, fmt.Errorf("unable to unmarshal NIU struct: %v", err)
+		return nil, err
 	}
 
 	// Get the operator's client ID
@@ -164,26 +171,17 @@ func (s *SmartContract) ReadNIU(sdk kalpsdk.TransactionContextInterface, id stri
 		return nil, fmt.Errorf("not a valid owner %v for the NIU asset with ID %v", niu.Account, id)
 	}
 
-	return &niu, nil
+	return niu, nil
 }
 
 // TransferNIU function transfers NIU tokens from senders to receivers
 // using KAPS contract functionality and updates the state of the asset in the world state.
 func (s *SmartContract) TransferNIU(sdk kalpsdk.TransactionContextInterface, senders []string, receivers []string, id string, docType string, amount uint64, timeStamp string) error {
 	// Retrieve asset from the world state using its ID
-	niuJSON, err := sdk.GetState(id)
-	if err != nil {
-		return fmt.Errorf("failed to read from world state: %v", err)
-	}
-	if niuJSON == nil {
-		return fmt.Errorf("the Asset %v does not exist", id)
-	}
-
-	// Unmarshal the asset JSON data into a struct
-	var niu NIU
-	err = json.Unmarshal(niuJSON, &niu)
+	repo := niuRepository(sdk)
+	niu, err := repo.MustGet(id)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal struct: %v", err)
+		return err
 	}
 
 	// // Check if DocType is valid
@@ -191,13 +189,13 @@ func (s *SmartContract) TransferNIU(sdk kalpsdk.TransactionContextInterface, sen
 	// 	return fmt.Errorf("invalid DocType, expected NIU or ASSET-NIU")
 	// }
 
-	// Check KYC status for each recipient
+	// Check KYC status of all recipients
+	kycChecks, err := sdk.GetKYCBatch(receivers)
+	if err != nil {
+		return err
+	}
 	for i := 0; i < len(receivers); i++ {
-		kycCheck, err := sdk.GetKYC(receivers[i])
-		if err != nil {
-			return fmt.Errorf("failed to perform KYC check for user:%s, error:%v", receivers[i], err)
-		}
-		if !kycCheck {
+		if !kycChecks[receivers[i]] {
 			return fmt.Errorf("user %s is not KYCed", receivers[i])
 		}
 		if slices.Contains(niu.Account, receivers[i]) {
@@ -209,12 +207,6 @@ func (s *SmartContract) TransferNIU(sdk kalpsdk.TransactionContextInterface, sen
 	//var OrgSenders = niu.Account
 	niu.Account = receivers
 
-	// Marshal the updated asset struct into JSON data and verify the asset hash
-	newniuJSON, err := json.Marshal(niu)
-	if err != nil {
-		return fmt.Errorf("failed to marshal struct: %v", err)
-	}
-
 	// Check if the asset is COMPLETED before transferring tokens
 	if niu.Status != statusCompleted {
 		return fmt.Errorf("asset is not applicable to Transfer")
@@ -227,11 +219,15 @@ func (s *SmartContract) TransferNIU(sdk kalpsdk.TransactionContextInterface, sen
 	// }
 
 	// Save the updated asset state in the world state
-	if err := sdk.PutStateWithKYC(id, newniuJSON); err != nil {
+	if err := repo.Update(id, niu); err != nil {
 		return fmt.Errorf("unable to put Asset struct in statedb: %v", err)
 	}
 
 	// Emit an event
+	newniuJSON, err := json.Marshal(niu)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %v", err)
+	}
 	if err := sdk.SetEvent("TransferNIU", newniuJSON); err != nil {
 		return fmt.Errorf("unable to setEvent TransferNIU: %v", err)
 	}
@@ -241,18 +237,10 @@ func (s *SmartContract) TransferNIU(sdk kalpsdk.TransactionContextInterface, sen
 // BurnTokens burns tokens associated with a given asset and deletes the asset from the world state
 func (s *SmartContract) BurnTokens(sdk kalpsdk.TransactionContextInterface, id string, account string, amount uint64) error {
 	// Retrieve the asset from the world state using its ID
-	niuJSON, err := sdk.GetState(id)
+	repo := niuRepository(sdk).WithoutKYC()
+	niu, err := repo.MustGet(id)
 	if err != nil {
-		return fmt.Errorf("failed to read from world state: %v", err)
-	}
-	if niuJSON == nil {
-		return fmt.Errorf("the asset %v does not exist", id)
-	}
-
-	// Unmarshal the asset JSON data into a struct
-	var niu NIU
-	if err := json.Unmarshal(niuJSON, &niu); err != nil {
-		return fmt.Errorf("failed to unmarshal struct: %v", err)
+		return err
 	}
 
 	// Get the operator's client ID
@@ -289,14 +277,57 @@ func (s *SmartContract) BurnTokens(sdk kalpsdk.TransactionContextInterface, id s
 	// }
 
 	// Delete the asset from the world state
-	if err := sdk.DelStateWithoutKYC(id); err != nil {
+	if err := repo.Delete(id); err != nil {
 		return fmt.Errorf("unable to delete asset struct in state database: %v", err)
 	}
 
 	// Emit an event indicating the asset has been deleted
+	niuJSON, err := json.Marshal(niu)
+	if err != nil {
+		return fmt.Errorf("failed to marshal struct: %v", err)
+	}
 	if err := sdk.SetEvent("DeleteNIU", niuJSON); err != nil {
 		return fmt.Errorf("unable to set event DeleteNIU: %v", err)
 	}
 
 	return nil
 }
+
+// ListNIUs returns a page of at most pageSize NIU assets held by the given account.
+// It takes the transaction context interface, the account, the page size and the bookmark returned with the previous page as input parameters.
+// Pass an empty bookmark to fetch the first page; the returned page holds the bookmark of the next one.
+func (s *SmartContract) ListNIUs(sdk kalpsdk.TransactionContextInterface, account string, pageSize int32, bookmark string) (*NIUPage, error) {
+	// Build a rich query matching the assets of the account
+	niuQuery, err := query.New(query.Selector{"account": query.ElemMatch(query.Eq(account))}).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %v", err)
+	}
+
+	// Fetch the page from the world state
+	iterator, metadata, err := sdk.GetQueryResultWithPagination(niuQuery, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NIU assets: %v", err)
+	}
+
+	page, err := kalpsdk.NewPage[NIU](iterator, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NIU assets: %v", err)
+	}
+	return (*NIUPage)(page), nil
+}
+
+// ListNIUsByOwner returns the NIU assets held by the given account, looked up through the account index.
+// It takes the transaction context interface, the account and an optional status as input parameters.
+// Pass an empty status to return the assets of the account in every status.
+func (s *SmartContract) ListNIUsByOwner(sdk kalpsdk.TransactionContextInterface, account string, status string) ([]NIU, error) {
+	values := []string{account}
+	if status != "" {
+		values = append(values, status)
+	}
+
+	nius, err := niuRepository(sdk).FindBy(niuAccountIndex.Name, values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NIU assets: %v", err)
+	}
+	return nius, nil
+}
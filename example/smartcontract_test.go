@@ -0,0 +1,159 @@
+package smartcontract
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/kalptest"
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+// newTestIdentity generates a client identity of Org1MSP with the given common name.
+func newTestIdentity(t *testing.T, name string) *kalptest.Identity {
+	t.Helper()
+	id, err := kalptest.NewIdentity(kalptest.IdentitySpec{CommonName: name, OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+	return id
+}
+
+func TestReadNIU(t *testing.T) {
+	harness, err := kalptest.NewHarness(&SmartContract{})
+	require.NoError(t, err)
+	harness.Stub.SetCommittedState("niu1", []byte(`{"id":"niu1","docType":"NIU","status":"COMPLETED","account":["alice"]}`))
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, harness.SetIdentity(newTestIdentity(t, "alice")))
+
+		var niu NIU
+		require.NoError(t, harness.Evaluate("ReadNIU", "niu1").Unmarshal(&niu))
+		require.Equal(t, []string{"alice"}, niu.Account)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.NoError(t, harness.SetIdentity(newTestIdentity(t, "bob")))
+
+		result := harness.Evaluate("ReadNIU", "niu1")
+		require.ErrorContains(t, result.Err(), "not a valid owner [alice] for the NIU asset with ID niu1")
+	})
+}
+
+func TestCreateAndTransferNIU(t *testing.T) {
+	harness, err := kalptest.NewHarness(&SmartContract{})
+	require.NoError(t, err)
+	kyc := kalptest.RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+	require.NoError(t, kyc.AddKYC("bob", "kyc2", "hash2"))
+	require.NoError(t, harness.SetIdentity(newTestIdentity(t, "alice")))
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		result := harness.Submit("CreateNIU", `{"id":"niu1","docType":"NIU","status":"COMPLETED","account":["alice"],"assetDigest":"digest"}`)
+		require.NoError(t, result.Err())
+
+		result = harness.Submit("TransferNIU", `["alice"]`, `["bob"]`, "niu1", "NIU", "1", "2023-01-01T00:00:00Z")
+		require.NoError(t, result.Err())
+		require.Equal(t, "TransferNIU", result.Event.EventName)
+
+		require.NoError(t, harness.SetIdentity(newTestIdentity(t, "bob")))
+		var niu NIU
+		require.NoError(t, harness.Evaluate("ReadNIU", "niu1").Unmarshal(&niu))
+		require.Equal(t, []string{"bob"}, niu.Account)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.NoError(t, harness.SetIdentity(newTestIdentity(t, "bob")))
+		result := harness.Submit("CreateNIU", `{"id":"niu1","docType":"NIU","status":"COMPLETED","account":["bob"],"assetDigest":"digest"}`)
+		require.ErrorContains(t, result.Err(), "the token with ID 'niu1' is already minted")
+
+		result = harness.Submit("TransferNIU", `["bob"]`, `["carol"]`, "niu1", "NIU", "1", "2023-01-01T00:00:00Z")
+		require.ErrorContains(t, result.Err(), "user carol is not KYCed")
+
+		require.NoError(t, harness.SetIdentity(newTestIdentity(t, "carol")))
+		result = harness.Submit("CreateNIU", `{"id":"niu2","docType":"NIU","status":"COMPLETED","account":["carol"],"assetDigest":"digest"}`)
+		require.ErrorContains(t, result.Err(), "user carol has not completed KYC")
+	})
+}
+
+func TestListNIUs(t *testing.T) {
+	harness, err := kalptest.NewHarness(&SmartContract{})
+	require.NoError(t, err)
+	for _, id := range []string{"niu1", "niu2", "niu3"} {
+		harness.Stub.SetCommittedState(id, []byte(`{"id":"`+id+`","docType":"NIU","status":"COMPLETED","account":["alice"]}`))
+	}
+	harness.Stub.SetCommittedState("niu4", []byte(`{"id":"niu4","docType":"NIU","status":"COMPLETED","account":["bob"]}`))
+
+	// Check that the pages can be followed with the bookmark
+	var page NIUPage
+	require.NoError(t, harness.Evaluate("ListNIUs", "alice", "2", "").Unmarshal(&page))
+	require.Len(t, page.Records, 2)
+	require.Equal(t, int32(2), page.FetchedRecordsCount)
+	require.Equal(t, "niu3", page.Bookmark)
+
+	page = NIUPage{}
+	require.NoError(t, harness.Evaluate("ListNIUs", "alice", "2", "niu3").Unmarshal(&page))
+	require.Len(t, page.Records, 1)
+	require.Equal(t, "niu3", page.Records[0].Id)
+	require.Empty(t, page.Bookmark)
+}
+
+func TestListNIUsByOwner(t *testing.T) {
+	harness, err := kalptest.NewHarness(&SmartContract{})
+	require.NoError(t, err)
+	kyc := kalptest.RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+	require.NoError(t, kyc.AddKYC("bob", "kyc2", "hash2"))
+	require.NoError(t, harness.SetIdentity(newTestIdentity(t, "alice")))
+	require.NoError(t, harness.Submit("CreateNIU", `{"id":"niu1","docType":"NIU","status":"COMPLETED","account":["alice"],"assetDigest":"digest"}`).Err())
+	require.NoError(t, harness.Submit("CreateNIU", `{"id":"niu2","docType":"NIU","status":"INPROGRESS","account":["alice"],"assetDigest":"digest"}`).Err())
+
+	// Check that the index follows the owners of the assets
+	var nius []NIU
+	require.NoError(t, harness.Evaluate("ListNIUsByOwner", "alice", "").Unmarshal(&nius))
+	require.Len(t, nius, 2)
+
+	nius = nil
+	require.NoError(t, harness.Evaluate("ListNIUsByOwner", "alice", "COMPLETED").Unmarshal(&nius))
+	require.Len(t, nius, 1)
+	require.Equal(t, "niu1", nius[0].Id)
+
+	require.NoError(t, harness.Submit("TransferNIU", `["alice"]`, `["bob"]`, "niu1", "NIU", "1", "2023-01-01T00:00:00Z").Err())
+
+	nius = nil
+	require.NoError(t, harness.Evaluate("ListNIUsByOwner", "alice", "COMPLETED").Unmarshal(&nius))
+	require.Empty(t, nius)
+
+	nius = nil
+	require.NoError(t, harness.Evaluate("ListNIUsByOwner", "bob", "").Unmarshal(&nius))
+	require.Len(t, nius, 1)
+	require.Equal(t, []string{"bob"}, nius[0].Account)
+}
+
+func TestNIUIndexFiles(t *testing.T) {
+	// Check that the generated index files match the index tags of NIU; run go generate after changing them
+	indexes, err := query.IndexesOf(NIU{})
+	require.NoError(t, err)
+	require.NotEmpty(t, indexes)
+
+	for _, index := range indexes {
+		data, err := os.ReadFile(filepath.Join("META-INF", "statedb", "couchdb", "indexes", index.FileName()))
+		require.NoError(t, err)
+
+		var generated query.Index
+		require.NoError(t, json.Unmarshal(data, &generated))
+		require.Equal(t, index, generated)
+	}
+
+	// Check that IsMinted queries can be served by the id and docType index
+	_, err = query.New(query.Selector{"id": "niu1", "docType": "NIU"}).UseIndexDefinition(indexes[0]).Build()
+	require.NoError(t, err)
+}
@@ -0,0 +1,132 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"errors"
+	"fmt"
+	"strings"
+
+	//Third party Libs
+	"golang.org/x/exp/slices"
+)
+
+// AccessDeniedError is returned when the client that submitted the transaction is not authorized to perform it,
+// e.g. because it lacks a required attribute, belongs to another MSP or has not completed KYC.
+type AccessDeniedError struct {
+	Reason string // Why access was denied.
+}
+
+// Error returns the reason access was denied.
+func (e *AccessDeniedError) Error() string {
+	return "access denied: " + e.Reason
+}
+
+// IsAccessDenied reports whether err is, or wraps, an *AccessDeniedError.
+func IsAccessDenied(err error) bool {
+	var accessDenied *AccessDeniedError
+	return errors.As(err, &accessDenied)
+}
+
+// accessDenied returns an *AccessDeniedError with a formatted reason.
+func accessDenied(format string, a ...interface{}) error {
+	return &AccessDeniedError{Reason: fmt.Sprintf(format, a...)}
+}
+
+// AccessCondition is a check on the client that submitted the transaction, used with RequireAnyOf. It returns an
+// *AccessDeniedError if the client does not satisfy the condition.
+type AccessCondition func(ctx TransactionContextInterface) error
+
+// HasAttribute returns a condition requiring the client certificate attribute `name` to equal `value`.
+func HasAttribute(name string, value string) AccessCondition {
+	return func(ctx TransactionContextInterface) error {
+		return ctx.RequireAttribute(name, value)
+	}
+}
+
+// HasMSP returns a condition requiring the client to belong to one of the given MSPs.
+func HasMSP(mspIDs ...string) AccessCondition {
+	return func(ctx TransactionContextInterface) error {
+		return ctx.RequireMSP(mspIDs...)
+	}
+}
+
+// RequireAttribute checks that the certificate of the client that submitted the transaction has the attribute
+// `name` with the value `value`, as issued by the Fabric CA.
+//
+// Parameters:
+//   - name: The name of the attribute, e.g. "role".
+//   - value: The required value of the attribute, e.g. "admin".
+//
+// Returns:
+//   - error: An *AccessDeniedError if the attribute is missing or has another value, or an error if the
+//     attributes could not be read.
+func (ctx *TransactionContext) RequireAttribute(name string, value string) error {
+	ci := ctx.GetClientIdentity()
+	if ci == nil {
+		return &IdentityError{Op: "failed to read attribute " + name, Err: ErrNoClientIdentity}
+	}
+
+	attrValue, found, err := ci.GetAttributeValue(name)
+	if err != nil {
+		return fmt.Errorf("failed to read attribute %s: %v", name, err)
+	}
+	if !found {
+		return accessDenied("attribute %s is required", name)
+	}
+	if attrValue != value {
+		return accessDenied("attribute %s must be %s", name, value)
+	}
+	return nil
+}
+
+// RequireMSP checks that the client that submitted the transaction belongs to one of the given MSPs.
+//
+// Parameters:
+//   - mspIDs: The IDs of the MSPs that are allowed, e.g. "Org1MSP".
+//
+// Returns:
+//   - error: An *AccessDeniedError if the client belongs to another MSP, or an error if the MSP ID could not be read.
+func (ctx *TransactionContext) RequireMSP(mspIDs ...string) error {
+	ci := ctx.GetClientIdentity()
+	if ci == nil {
+		return &IdentityError{Op: "failed to read MSP ID", Err: ErrNoClientIdentity}
+	}
+
+	mspID, err := ci.GetMSPID()
+	if err != nil {
+		return fmt.Errorf("failed to read MSP ID: %v", err)
+	}
+	if !slices.Contains(mspIDs, mspID) {
+		return accessDenied("MSP %s is not one of %v", mspID, mspIDs)
+	}
+	return nil
+}
+
+// RequireAnyOf checks that the client that submitted the transaction satisfies at least one of the conditions.
+// The conditions are checked in order and checking stops at the first one that is satisfied.
+//
+// Parameters:
+//   - conditions: The conditions, e.g. HasAttribute("role", "admin") and HasMSP("Org1MSP").
+//
+// Returns:
+//   - error: An *AccessDeniedError listing why each condition failed if none is satisfied, or the first error
+//     that is not an access denial.
+func (ctx *TransactionContext) RequireAnyOf(conditions ...AccessCondition) error {
+	if len(conditions) == 0 {
+		return accessDenied("no access condition given")
+	}
+
+	reasons := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		err := condition(ctx)
+		if err == nil {
+			return nil
+		}
+		var denied *AccessDeniedError
+		if !errors.As(err, &denied) {
+			return err
+		}
+		reasons = append(reasons, denied.Reason)
+	}
+	return accessDenied("none of the conditions is satisfied: %s", strings.Join(reasons, "; "))
+}
@@ -0,0 +1,97 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"errors"
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireAttribute(t *testing.T) {
+	mockClientIdentity := new(mocks.ClientIdentity)
+	ctx := &TransactionContext{clientIdentity: mockClientIdentity}
+	mockClientIdentity.On("GetAttributeValue", "role").Return("admin", true, nil)
+	mockClientIdentity.On("GetAttributeValue", "department").Return("", false, nil)
+	mockClientIdentity.On("GetAttributeValue", "broken").Return("", false, fmt.Errorf("bad extension"))
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, ctx.RequireAttribute("role", "admin"))
+	})
+
+	// Check for wrong value
+	t.Run("Check for wrong value", func(t *testing.T) {
+		err := ctx.RequireAttribute("role", "auditor")
+		require.EqualError(t, err, "access denied: attribute role must be auditor")
+		require.True(t, IsAccessDenied(err))
+	})
+
+	// Check for missing attribute
+	t.Run("Check for missing attribute", func(t *testing.T) {
+		err := ctx.RequireAttribute("department", "sales")
+		require.EqualError(t, err, "access denied: attribute department is required")
+		require.True(t, IsAccessDenied(err))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		err := ctx.RequireAttribute("broken", "x")
+		require.EqualError(t, err, "failed to read attribute broken: bad extension")
+		require.False(t, IsAccessDenied(err))
+
+		err = (&TransactionContext{}).RequireAttribute("role", "admin")
+		require.True(t, errors.Is(err, ErrNoClientIdentity))
+	})
+}
+
+func TestRequireMSP(t *testing.T) {
+	mockClientIdentity := new(mocks.ClientIdentity)
+	ctx := &TransactionContext{clientIdentity: mockClientIdentity}
+	mockClientIdentity.On("GetMSPID").Return("Org1MSP", nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, ctx.RequireMSP("Org1MSP"))
+		require.NoError(t, ctx.RequireMSP("Org2MSP", "Org1MSP"))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		err := ctx.RequireMSP("Org2MSP")
+		require.EqualError(t, err, "access denied: MSP Org1MSP is not one of [Org2MSP]")
+		require.True(t, IsAccessDenied(err))
+	})
+}
+
+func TestRequireAnyOf(t *testing.T) {
+	mockClientIdentity := new(mocks.ClientIdentity)
+	ctx := &TransactionContext{clientIdentity: mockClientIdentity}
+	mockClientIdentity.On("GetMSPID").Return("Org1MSP", nil)
+	mockClientIdentity.On("GetAttributeValue", "role").Return("auditor", true, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, ctx.RequireAnyOf(HasAttribute("role", "admin"), HasMSP("Org1MSP")))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		err := ctx.RequireAnyOf(HasAttribute("role", "admin"), HasMSP("Org2MSP"))
+		require.EqualError(t, err, "access denied: none of the conditions is satisfied: attribute role must be admin; MSP Org1MSP is not one of [Org2MSP]")
+		require.True(t, IsAccessDenied(err))
+
+		require.EqualError(t, ctx.RequireAnyOf(), "access denied: no access condition given")
+	})
+
+	// Check that errors other than access denials are returned
+	t.Run("Check for condition error", func(t *testing.T) {
+		failing := func(ctx TransactionContextInterface) error {
+			return fmt.Errorf("ledger unavailable")
+		}
+		require.EqualError(t, ctx.RequireAnyOf(failing, HasMSP("Org1MSP")), "ledger unavailable")
+	})
+}
@@ -0,0 +1,94 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"sort"
+	"strings"
+)
+
+// RoleAttribute is the client certificate attribute checked against AccessPolicy.Roles.
+const RoleAttribute = "role"
+
+// AccessPolicy describes who may call a contract function. Every requirement that is set must be met; a zero
+// AccessPolicy allows everyone.
+type AccessPolicy struct {
+	Roles      []string          // The client must have one of these values in its role attribute; empty allows any role.
+	MSPs       []string          // The client must belong to one of these MSPs; empty allows any MSP.
+	Attributes map[string]string // Certificate attributes the client must all have, with the given values.
+	KYCLevel   KYCLevel          // The minimum KYC level of the client; KYCLevelNone skips the KYC check.
+	Conditions []AccessCondition // Additional conditions the client must all satisfy.
+}
+
+// Check checks that the client that submitted the transaction meets the policy.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//
+// Returns:
+//   - error: An *AccessDeniedError for the first requirement that is not met, or an error if a requirement could
+//     not be checked.
+func (p AccessPolicy) Check(ctx TransactionContextInterface) error {
+	if len(p.MSPs) > 0 {
+		if err := ctx.RequireMSP(p.MSPs...); err != nil {
+			return err
+		}
+	}
+
+	if len(p.Roles) > 0 {
+		roles := make([]AccessCondition, 0, len(p.Roles))
+		for _, role := range p.Roles {
+			roles = append(roles, HasAttribute(RoleAttribute, role))
+		}
+		if err := ctx.RequireAnyOf(roles...); err != nil {
+			return err
+		}
+	}
+
+	// Check the attributes in a fixed order so that the reported requirement does not vary between peers
+	names := make([]string, 0, len(p.Attributes))
+	for name := range p.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := ctx.RequireAttribute(name, p.Attributes[name]); err != nil {
+			return err
+		}
+	}
+
+	for _, condition := range p.Conditions {
+		if err := condition(ctx); err != nil {
+			return err
+		}
+	}
+
+	if p.KYCLevel > KYCLevelNone {
+		if err := ctx.RequireKYCLevel(p.KYCLevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAccessPolicy checks the policy registered for the function being invoked, if any.
+func (c *Contract) checkAccessPolicy(ctx TransactionContextInterface) error {
+	if len(c.AccessPolicies) == 0 {
+		return nil
+	}
+
+	policy, ok := c.AccessPolicies[invokedFunction(ctx)]
+	if !ok {
+		return nil
+	}
+	return policy.Check(ctx)
+}
+
+// invokedFunction returns the name of the contract function being invoked.
+func invokedFunction(ctx TransactionContextInterface) string {
+	fnName, _ := ctx.GetFunctionAndParameters()
+	// Functions of named contracts are invoked as "ContractName:FunctionName"
+	if i := strings.LastIndex(fnName, ":"); i >= 0 {
+		fnName = fnName[i+1:]
+	}
+	return fnName
+}
@@ -0,0 +1,89 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicyCheck(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	mockClientIdentity := new(mocks.ClientIdentity)
+	ctx := &TransactionContext{clientIdentity: mockClientIdentity}
+	ctx.SetStub(mockStub)
+	ctx.SetKYCProvider(KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+		return KYCStatus{Level: KYCLevelBasic}, nil
+	}))
+
+	mockStub.On("GetTxID").Return("tx1")
+	mockClientIdentity.On("GetID").Return(testOwnerID, nil)
+	mockClientIdentity.On("GetMSPID").Return("Org1MSP", nil)
+	mockClientIdentity.On("GetAttributeValue", "role").Return("minter", true, nil)
+	mockClientIdentity.On("GetAttributeValue", "department").Return("sales", true, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, AccessPolicy{}.Check(ctx))
+		require.NoError(t, AccessPolicy{
+			Roles:      []string{"admin", "minter"},
+			MSPs:       []string{"Org1MSP"},
+			Attributes: map[string]string{"department": "sales"},
+			KYCLevel:   KYCLevelBasic,
+		}.Check(ctx))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		tests := []struct {
+			policy AccessPolicy
+			err    string
+		}{
+			{AccessPolicy{MSPs: []string{"Org2MSP"}}, "access denied: MSP Org1MSP is not one of [Org2MSP]"},
+			{AccessPolicy{Roles: []string{"admin"}}, "access denied: none of the conditions is satisfied: attribute role must be admin"},
+			{AccessPolicy{Attributes: map[string]string{"department": "hr"}}, "access denied: attribute department must be hr"},
+			{AccessPolicy{Conditions: []AccessCondition{HasMSP("Org3MSP")}}, "access denied: MSP Org1MSP is not one of [Org3MSP]"},
+			{AccessPolicy{KYCLevel: KYCLevelEnhanced}, "access denied: user TestOwner has KYC level basic, but enhanced is required"},
+		}
+		for _, tc := range tests {
+			err := tc.policy.Check(ctx)
+			require.EqualError(t, err, tc.err)
+			require.True(t, IsAccessDenied(err))
+		}
+	})
+}
+
+func TestGetBeforeTransactionWithAccessPolicies(t *testing.T) {
+	beforeCalled := false
+	contract := Contract{AccessPolicies: map[string]AccessPolicy{"Mint": {MSPs: []string{"Org1MSP"}}}}
+	contract.BeforeTransaction = func() { beforeCalled = true }
+	beforeFn, ok := contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	require.True(t, ok, "should wrap the before transaction when access policies are set")
+
+	newContext := func(fnName string, mspID string) *TransactionContext {
+		mockStub := new(mocks.ChaincodeStubInterface)
+		mockClientIdentity := new(mocks.ClientIdentity)
+		mockStub.On("GetFunctionAndParameters").Return(fnName, []string{})
+		mockClientIdentity.On("GetMSPID").Return(mspID, nil)
+		ctx := &TransactionContext{clientIdentity: mockClientIdentity}
+		ctx.SetStub(mockStub)
+		return ctx
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, beforeFn(newContext("Mint", "Org1MSP")))
+		require.True(t, beforeCalled)
+		require.NoError(t, beforeFn(newContext("Burn", "Org2MSP")))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		beforeCalled = false
+		err := beforeFn(newContext("Token:Mint", "Org2MSP"))
+		require.EqualError(t, err, "access denied: MSP Org2MSP is not one of [Org1MSP]")
+		require.False(t, beforeCalled, "should not call the before transaction when access is denied")
+	})
+}
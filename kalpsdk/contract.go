@@ -13,8 +13,8 @@ package kalpsdk
 
 import (
 	//Standard Libs
-	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
 	//Third party Libs
@@ -26,17 +26,27 @@ import (
 // and name. Can be embedded in structs to quickly ensure their definition meets the
 // ContractInterface.
 type Contract struct {
-	Logger            *ChaincodeLogger
-	IsPayableContract bool
+	Logger               *ChaincodeLogger
+	IsPayableContract    bool                       // Every function except the EvaluateTransactions takes a payment as its last argument.
+	PayableFunctions     map[string]PayableFunction // Functions taking a payment as their last argument, with their price and currency.
+	EvaluateTransactions []string                   // Functions that only read the ledger; they never take a payment.
+	KYCConfig            *KYCConfig                 // KYC configuration of the contract's transactions; nil falls back to the init, environment and default configuration.
+	KYCProvider          KYCProvider                // KYC provider of the contract's transactions; nil uses ChaincodeKYCProvider.
+	AccessPolicies       map[string]AccessPolicy    // Access policies by function name, checked before the function is called.
+	PaymentRules         *PaymentRules              // Rules the payments of a payable contract must meet; nil accepts any valid payment.
+	evaluateTransactions []string                   // Evaluate functions of the contract embedding this one, set by NewChaincode.
 	contractapi.Contract
 }
 
 // PaymentTracker represents the payment tracking information associated with a transaction on the Kalptantra blockchain network.
 // The struct is used for storing and retrieving payment-related data.
+//
+// The amount is encoded as a Money object; the legacy format, where amount is a float in the currency given by
+// currencyCode, is still accepted and parsed exactly.
 type PaymentMetaData struct {
-	Amount                 float64   `json:”amount”`                        // Amount of the payment
-	CurrencyCode           string    `json:”currencyCode”`                  // Currency Code of the payment
-	paymentTimestamp       time.Time `json:"paymentTimestamp"`              // Timestamp of the Payment
+	Amount                 Money     `json:"amount"`       // Amount of the payment
+	CurrencyCode           string    `json:"currencyCode"` // Currency Code of the payment, the same as Amount.Currency
+	paymentTimestamp       time.Time // Timestamp of the Payment, not serialized
 	ApplicationReferenceId string    `json:"applicationReferenceId"`        // ID of the application or uuid of Payment Engine
 	IsPaymentEngineUsed    bool      `json:"isPaymentEngineUsed,omitempty"` // If Payment Engine used or not, default value should be true
 }
@@ -44,12 +54,14 @@ type PaymentMetaData struct {
 type PaymentTracker struct {
 	TransactionId        string          `json:"transactionId"`        // The ID of the transaction.
 	DocType              string          `json:"DocType"`              // The type of the document it must be PAYMENT-INFO.
-	PaymentTransactionID string          `json:”paymentTransactionId"` // The reference number of the payment.
-	PaymentGatewayName   string          `json:"paymentGatewayName`    // The Name of the payment gateway.
+	Payer                string          `json:"payer,omitempty"`      // The ID of the user that submitted the transaction.
+	Timestamp            time.Time       `json:"timestamp"`            // The timestamp of the transaction.
+	PaymentTransactionID string          `json:"paymentTransactionId"` // The reference number of the payment.
+	PaymentGatewayName   string          `json:"paymentGatewayName"`   // The Name of the payment gateway.
 	PaymentMetaData      PaymentMetaData `json:"paymentMetaData"`      // Additional metadata related to the payment.
-	AssetInfo            interface{}     `json:"assetInfo,omitempty”`  // Information about the associated asset.
-	AssetId              string          `json:"id,omitempty”`
-	AssetDocType         string          `json:"docType,omitempty”`
+	AssetInfo            interface{}     `json:"assetInfo,omitempty"`  // Information about the associated asset.
+	AssetId              string          `json:"id,omitempty"`
+	AssetDocType         string          `json:"docType,omitempty"`
 }
 
 // NewChaincode creates a new chaincode using the contracts passed as arguments. Each of the passed contracts
@@ -72,6 +84,19 @@ type PaymentTracker struct {
 //   - *ContractChaincode: The initialized ContractChaincode instance.
 //   - error: An error if there was a failure in creating the chaincode.
 func NewChaincode(contracts ...contractapi.ContractInterface) (*ContractChaincode, error) {
+	for _, contract := range contracts {
+		if embedded, ok := contract.(interface{ kalpContract() *Contract }); ok {
+			if err := embedded.kalpContract().prepare(contract); err != nil {
+				return nil, fmt.Errorf("failed to create chaincode: %v", err)
+			}
+			// GetBeforeTransaction may wrap the before transaction, hiding it from the checks of contractapi
+			ctxType := reflect.TypeOf(contract.GetTransactionContextHandler())
+			if err := checkTransactionHandler(embedded.kalpContract().BeforeTransaction, ctxType); err != nil {
+				return nil, fmt.Errorf("failed to create chaincode: invalid before transaction of %s: %v", reflect.TypeOf(contract), err)
+			}
+		}
+	}
+
 	chaincode, err := contractapi.NewChaincode(contracts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create chaincode: %v", err)
@@ -98,74 +123,142 @@ func (c *Contract) GetUnknownTransaction() interface{} {
 	return c.UnknownTransaction
 }
 
-// GetBeforeTransaction returns the current set beforeTransaction, may be nil
+// GetBeforeTransaction returns the current set beforeTransaction, may be nil.
+// If the contract has a KYCConfig, a KYCProvider, AccessPolicies or payable functions, the returned function sets
+// the KYC settings on the transaction context, checks the access policy of the invoked function and validates the
+// payment of a payable function before calling the beforeTransaction that was set. The validated payment is
+// available to the function through GetPayment.
 func (c *Contract) GetBeforeTransaction() interface{} {
-	return c.BeforeTransaction
+	if c.KYCConfig == nil && c.KYCProvider == nil && len(c.AccessPolicies) == 0 && !c.hasPayableFunctions() {
+		return c.BeforeTransaction
+	}
+
+	beforeFunction := func(ctx TransactionContextInterface) error {
+		if settable, ok := ctx.(kycConfigSettable); ok && c.KYCConfig != nil {
+			settable.SetKYCConfig(*c.KYCConfig)
+		}
+		if settable, ok := ctx.(kycProviderSettable); ok && c.KYCProvider != nil {
+			settable.SetKYCProvider(c.KYCProvider)
+		}
+		if err := c.checkAccessPolicy(ctx); err != nil {
+			return err
+		}
+		if err := c.checkPayment(ctx); err != nil {
+			return err
+		}
+		return callTransactionHandler(c.BeforeTransaction, ctx)
+	}
+	return beforeFunction
 }
 
 // GetAfterTransaction returns the current set afterTransaction, which is a function to be executed after each transaction.
 // The returned function takes two parameters: the transaction context and the result of the transaction.
 // It performs post-transaction operations such as payment processing or data persistence.
-// If the contract is a payable contract, the payment operation is implemented based on the transaction type.
+// If the invoked function is payable, the payment is recorded under the transaction ID, see GetPaymentRecord.
 func (c *Contract) GetAfterTransaction() interface{} {
 	fmt.Println("GetAfterTransaction Called once while install chaincode")
 	c.Logger = NewLogger()
 	setupChaincodeLogging()
 
-	assetMap := make(map[string]interface{})
 	// afterFunction is an anonymous function that will be executed after each transaction
 	afterFunction := func(ctx TransactionContextInterface) error {
-		c.Logger.Println("After Transaction:", ctx.GetTxID(), "IsPayable is:", c.IsPayableContract)
-		if c.IsPayableContract {
-
-			// Retrieve the function name and arguments of the executed transaction
-			fnName, args := ctx.GetFunctionAndParameters()
-			c.Logger.Println("args:", fnName, args)
+		isPayable := c.hasPayableFunctions() && c.isPayable(invokedFunction(ctx))
+		c.Logger.Println("After Transaction:", ctx.GetTxID(), "IsPayable is:", isPayable)
+		if isPayable {
 
-			var paymentTracker PaymentTracker
-			inputData := args[len(args)-1]
+			// The payment was validated before the transaction; contexts that cannot hold it parse it again
+			payment := ctx.GetPayment()
+			if payment == nil {
+				var err error
+				if payment, err = c.preparePayment(ctx); err != nil {
+					return err
+				}
+			}
 
-			err := json.Unmarshal([]byte(inputData), &paymentTracker)
-			if err != nil {
+			if err := putPaymentRecord(ctx, *payment); err != nil {
 				return err
 			}
+			c.Logger.Println("Successfully triggered the After operations for the transaction")
+		}
+		return nil
+	}
+	return afterFunction
+}
 
-			err = json.Unmarshal([]byte(inputData), &assetMap)
-			if err != nil {
-				return fmt.Errorf("failed to parse input data of asset: %v", err)
-			}
+// kycConfigSettable is implemented by transaction contexts that accept a KYCConfig.
+type kycConfigSettable interface {
+	SetKYCConfig(config KYCConfig)
+}
 
-			if !c.CheckPaymentDetails(paymentTracker) {
-				return fmt.Errorf("payment transaction does not have valid amount or currencycode!")
-			}
+// kycProviderSettable is implemented by transaction contexts that accept a KYCProvider.
+type kycProviderSettable interface {
+	SetKYCProvider(provider KYCProvider)
+}
 
-			// Update the paymentTracker fields
-			paymentTracker.DocType = "PAYMENT-INFO"
-			paymentTracker.TransactionId = ctx.GetTxID()
+// callTransactionHandler calls a before, after or unknown transaction function set on the contract with the
+// transaction context, and returns the error it returned, if any. A nil function is ignored.
+func callTransactionHandler(fn interface{}, ctx TransactionContextInterface) error {
+	if fn == nil {
+		return nil
+	}
+	if err := checkTransactionHandler(fn, reflect.TypeOf(ctx)); err != nil {
+		return err
+	}
 
-			if Id, err := assetMap["id"]; err {
-				paymentTracker.AssetId = Id.(string)
-			}
-			if DocType, err := assetMap["docType"]; err {
-				paymentTracker.AssetDocType = DocType.(string)
-			}
+	var args []reflect.Value
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Type().NumIn() == 1 {
+		args = append(args, reflect.ValueOf(ctx))
+	}
 
-			// Marshal the updated paymentTracker object into JSON
-			paymentData, err := json.Marshal(paymentTracker)
-			if err != nil {
-				return err
-			}
+	results := fnValue.Call(args)
+	if len(results) == 0 {
+		return nil
+	}
+	if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
+		return err
+	}
+	return nil
+}
 
-			// Put the paymentData to the ledger using PutStateWithKyc
-			err = ctx.PutStateWithKYC(paymentTracker.TransactionId, paymentData)
-			if err != nil {
-				return err
-			}
-			c.Logger.Println("Successfully triggered the After operations for the transaction")
-		}
+// checkTransactionHandler checks that a before, after or unknown transaction function can be called with a
+// transaction context of type ctxType, the same way contractapi checks the functions it calls itself. A nil function
+// is valid.
+func checkTransactionHandler(fn interface{}, ctxType reflect.Type) error {
+	if fn == nil {
 		return nil
 	}
-	return afterFunction
+
+	fnType := reflect.TypeOf(fn)
+	if fnType.Kind() != reflect.Func {
+		return fmt.Errorf("transaction handler must be a function, got %s", fnType)
+	}
+
+	switch fnType.NumIn() {
+	case 0:
+	case 1:
+		if !ctxType.AssignableTo(fnType.In(0)) {
+			return fmt.Errorf("transaction handler takes %s, which the transaction context %s does not satisfy", fnType.In(0), ctxType)
+		}
+	default:
+		return fmt.Errorf("transaction handler may not take any params other than the transaction context")
+	}
+
+	if fnType.NumOut() > 2 {
+		return fmt.Errorf("transaction handler may only return a maximum of two values, got %d", fnType.NumOut())
+	}
+	if errorType := reflect.TypeOf((*error)(nil)).Elem(); fnType.NumOut() == 2 && fnType.Out(1) != errorType {
+		return fmt.Errorf("transaction handler must return an error as its second value, got %s", fnType.Out(1))
+	}
+	return nil
+}
+
+// CheckPaymentDetails reports whether the payment has a valid, positive amount in an ISO 4217 currency. Fractional
+// amounts such as 0.50 INR are accepted, as long as they are not more precise than the minor units of the currency.
+// It does not check the PaymentRules of the contract; the before transaction hook of a payable function does, and
+// fails the transaction with the reason the payment is rejected.
+func (c *Contract) CheckPaymentDetails(PaymentDetails PaymentTracker) bool {
+	return checkPaymentDetails(PaymentDetails) == nil
 }
 
 // GetName returns the name of the contract.
@@ -173,14 +266,6 @@ func (c *Contract) GetAfterTransaction() interface{} {
 //
 // Returns:
 //   - string: The name of the contract.
-
-func (c *Contract) CheckPaymentDetails(PaymentDetails PaymentTracker) bool {
-	if PaymentDetails.PaymentMetaData.Amount < 1 || PaymentDetails.PaymentMetaData.CurrencyCode == "" {
-		return false
-	}
-	return true
-}
-
 func (c *Contract) GetName() string {
 	return c.Name
 }
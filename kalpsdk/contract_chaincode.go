@@ -20,8 +20,16 @@ type ContractChaincode struct {
 
 // Init is called during Instantiate transaction after the chaincode container
 // has been established for the first time, passes off details of the request to Invoke
-// for handling the request if a function name is passed, otherwise returns shim.Success
+// for handling the request if a function name is passed, otherwise returns shim.Success.
+// If the function name is KYCConfigInitFunction, the JSON encoded KYCConfig passed as
+// the argument is stored on the ledger and used by all following transactions.
 func (kc *ContractChaincode) Init(stub ChaincodeStubInterface) peer.Response {
+	if fn, params := stub.GetFunctionAndParameters(); fn == KYCConfigInitFunction {
+		if err := putStoredKYCConfig(stub, params); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	}
 	return kc.ContractChaincode.Init(stub)
 }
 
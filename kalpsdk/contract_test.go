@@ -2,6 +2,7 @@ package kalpsdk
 
 import (
 	//Standard Libs
+	"fmt"
 	"testing"
 
 	//Third party Libs
@@ -55,6 +56,80 @@ func TestGetBeforeTransaction(t *testing.T) {
 	require.Equal(t, ReturnsString(), beforeFn.(func() string)(), "function returned should be same value as set for before transaction")
 }
 
+func TestGetBeforeTransactionWithKYCConfig(t *testing.T) {
+	contract := Contract{KYCConfig: &KYCConfig{ChaincodeName: "universalkyc"}}
+
+	called := false
+	contract.BeforeTransaction = func(ctx *customContext) error {
+		called = true
+		return fmt.Errorf("before failed")
+	}
+	beforeFn, ok := contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	require.True(t, ok, "should wrap the before transaction when a KYC config is set")
+
+	// Checked the KYC config is set on the context and the before transaction is called
+	ctx := &customContext{}
+	require.EqualError(t, beforeFn(ctx), "before failed")
+	require.True(t, called)
+	require.Equal(t, &KYCConfig{ChaincodeName: "universalkyc"}, ctx.kycConfig)
+
+	// Checked a before transaction taking an incompatible context is rejected
+	contract.BeforeTransaction = func(ctx *TransactionContext) {}
+	beforeFn = contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	require.Error(t, beforeFn(&customContext{}))
+
+	// Checked the wrapper works without a before transaction
+	contract.BeforeTransaction = nil
+	beforeFn = contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	require.NoError(t, beforeFn(&TransactionContext{}))
+}
+
+func TestNewChaincodeChecksBeforeTransaction(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		contract := &payableTestContract{}
+		contract.KYCConfig = &KYCConfig{ChaincodeName: "universalkyc"}
+		contract.BeforeTransaction = func(ctx TransactionContextInterface) error { return nil }
+		_, err := NewChaincode(contract)
+		require.NoError(t, err)
+	})
+
+	// Check that a wrapped before transaction with a bad signature fails when the chaincode is created
+	t.Run("Check for failure response", func(t *testing.T) {
+		tests := map[string]interface{}{
+			"transaction handler may not take any params other than the transaction context":                                               func(ctx TransactionContextInterface, id string) error { return nil },
+			"transaction handler takes *kalpsdk.customContext, which the transaction context *kalpsdk.TransactionContext does not satisfy": func(ctx *customContext) error { return nil },
+			"transaction handler must return an error as its second value, got int":                                                        func() (string, int) { return "", 0 },
+			"transaction handler must be a function, got string":                                                                           "before",
+		}
+		for expected, before := range tests {
+			contract := &payableTestContract{}
+			contract.AccessPolicies = map[string]AccessPolicy{"Read": {}}
+			contract.BeforeTransaction = before
+			_, err := NewChaincode(contract)
+			require.EqualError(t, err, "failed to create chaincode: invalid before transaction of *kalpsdk.payableTestContract: "+expected)
+		}
+	})
+}
+
+func TestGetBeforeTransactionWithKYCProvider(t *testing.T) {
+	provider := KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+		return KYCStatus{Level: KYCLevelEnhanced}, nil
+	})
+	contract := Contract{KYCProvider: provider}
+	beforeFn, ok := contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	require.True(t, ok, "should wrap the before transaction when a KYC provider is set")
+
+	// Checked the KYC provider is set on the context
+	ctx := &TransactionContext{}
+	require.NoError(t, beforeFn(ctx))
+	require.NotNil(t, ctx.kycProvider)
+	require.Nil(t, ctx.kycConfig)
+
+	// Checked the default provider is used otherwise
+	require.Equal(t, ChaincodeKYCProvider{}, (&TransactionContext{}).GetKYCProvider())
+}
+
 func TestGetUnknownTransaction(t *testing.T) {
 	var contract Contract
 	var unknownFn interface{}
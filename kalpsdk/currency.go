@@ -0,0 +1,80 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// iso4217CSV is the table of the active ISO 4217 currencies: code, numeric code, minor units and name.
+//
+//go:embed iso4217.csv
+var iso4217CSV string
+
+// iso4217 holds the active ISO 4217 currencies by code.
+var iso4217 = parseISO4217(iso4217CSV)
+
+// Currency is an active ISO 4217 currency.
+type Currency struct {
+	Code       string // The alphabetic code, e.g. "INR".
+	Number     string // The numeric code, e.g. "356".
+	MinorUnits int    // The number of decimal places, e.g. 2.
+	Name       string // The name of the currency, e.g. "Indian Rupee".
+}
+
+// LookupCurrency returns the ISO 4217 currency with the given alphabetic code.
+//
+// Parameters:
+//   - code: The alphabetic code of the currency, in upper case.
+//
+// Returns:
+//   - Currency: The currency.
+//   - bool: false if the code is not an active ISO 4217 currency.
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := iso4217[code]
+	return currency, ok
+}
+
+// ValidateCurrency checks that a code is the alphabetic code of an active ISO 4217 currency.
+//
+// Parameters:
+//   - code: The code to check.
+//
+// Returns:
+//   - error: An error describing why the code is not a valid currency code.
+func ValidateCurrency(code string) error {
+	if code == "" {
+		return fmt.Errorf("currency code must not be empty")
+	}
+	if _, ok := iso4217[code]; !ok {
+		if upper := strings.ToUpper(code); upper != code {
+			if _, ok := iso4217[upper]; ok {
+				return fmt.Errorf("currency code %q must be in upper case, %s", code, upper)
+			}
+		}
+		return fmt.Errorf("currency code %q is not an ISO 4217 currency", code)
+	}
+	return nil
+}
+
+// parseISO4217 parses the embedded currency table. It panics if the table is malformed, which the tests catch.
+func parseISO4217(table string) map[string]Currency {
+	records, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("invalid ISO 4217 table: %v", err))
+	}
+
+	currencies := make(map[string]Currency, len(records))
+	// The first record is the header
+	for _, record := range records[1:] {
+		minorUnits, err := strconv.Atoi(record[2])
+		if err != nil {
+			panic(fmt.Sprintf("invalid minor units of %s in the ISO 4217 table: %v", record[0], err))
+		}
+		currencies[record[0]] = Currency{Code: record[0], Number: record[1], MinorUnits: minorUnits, Name: record[3]}
+	}
+	return currencies
+}
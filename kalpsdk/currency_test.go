@@ -0,0 +1,59 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		currency, ok := LookupCurrency("INR")
+		require.True(t, ok)
+		require.Equal(t, Currency{Code: "INR", Number: "356", MinorUnits: 2, Name: "Indian Rupee"}, currency)
+
+		currency, ok = LookupCurrency("ALL")
+		require.True(t, ok)
+		require.Equal(t, "008", currency.Number)
+
+		require.Equal(t, 0, MinorUnits("JPY"))
+		require.Equal(t, 3, MinorUnits("KWD"))
+		require.Equal(t, 4, MinorUnits("CLF"))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, ok := LookupCurrency("inr")
+		require.False(t, ok)
+		_, ok = LookupCurrency("XAU")
+		require.False(t, ok)
+	})
+}
+
+func TestISO4217Table(t *testing.T) {
+	numbers := map[string]string{}
+	for code, currency := range iso4217 {
+		require.Regexp(t, `^[A-Z]{3}$`, code)
+		require.Regexp(t, `^[0-9]{3}$`, currency.Number, code)
+		require.Contains(t, []int{0, 2, 3, 4}, currency.MinorUnits, code)
+		require.NotEmpty(t, currency.Name, code)
+		require.NotContains(t, numbers, currency.Number, "%s and %s share a numeric code", code, numbers[currency.Number])
+		numbers[currency.Number] = code
+	}
+	require.Len(t, iso4217, 166)
+}
+
+func TestValidateCurrency(t *testing.T) {
+	// Check for success response
+	require.NoError(t, ValidateCurrency("INR"))
+	require.NoError(t, ValidateCurrency("USD"))
+
+	// Check for failure response
+	require.EqualError(t, ValidateCurrency(""), "currency code must not be empty")
+	require.EqualError(t, ValidateCurrency("inr"), `currency code "inr" must be in upper case, INR`)
+	require.EqualError(t, ValidateCurrency("ABC"), `currency code "ABC" is not an ISO 4217 currency`)
+	require.EqualError(t, ValidateCurrency("KLP"), `currency code "KLP" is not an ISO 4217 currency`)
+}
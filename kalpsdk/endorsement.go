@@ -0,0 +1,358 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"sort"
+
+	//Third party Libs
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// RoleType is the role an organization's endorsing identity must have under an EndorsementPolicy.
+type RoleType string
+
+const (
+	// RoleTypeMember accepts any member of the organization.
+	RoleTypeMember = RoleType("MEMBER")
+	// RoleTypePeer accepts only peers of the organization.
+	RoleTypePeer = RoleType("PEER")
+	// RoleTypeAdmin accepts only admins of the organization.
+	RoleTypeAdmin = RoleType("ADMIN")
+	// RoleTypeClient accepts only clients of the organization.
+	RoleTypeClient = RoleType("CLIENT")
+)
+
+// mspRoles maps the role types to their MSP roles.
+var mspRoles = map[RoleType]msp.MSPRole_MSPRoleType{
+	RoleTypeMember: msp.MSPRole_MEMBER,
+	RoleTypePeer:   msp.MSPRole_PEER,
+	RoleTypeAdmin:  msp.MSPRole_ADMIN,
+	RoleTypeClient: msp.MSPRole_CLIENT,
+}
+
+// EndorsementPolicy builds a key-level endorsement policy requiring N out of a set of organizations to endorse
+// changes to a key. Without a threshold, every organization of the policy must endorse.
+type EndorsementPolicy struct {
+	orgs      map[string]RoleType
+	threshold int
+}
+
+// NewEndorsementPolicy creates an empty endorsement policy.
+//
+// Returns:
+//   - *EndorsementPolicy: The policy without organizations.
+func NewEndorsementPolicy() *EndorsementPolicy {
+	return &EndorsementPolicy{orgs: make(map[string]RoleType)}
+}
+
+// ParseEndorsementPolicy decodes a policy serialized by EndorsementPolicy.Policy, as returned by
+// GetStateValidationParameter. Only policies requiring N out of a list of organization roles are supported.
+//
+// Parameters:
+//   - policy: The serialized SignaturePolicyEnvelope.
+//
+// Returns:
+//   - *EndorsementPolicy: The decoded policy.
+//   - error: An error if the policy could not be decoded or is not an N out of organizations policy.
+func ParseEndorsementPolicy(policy []byte) (*EndorsementPolicy, error) {
+	envelope := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policy, envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal endorsement policy: %v", err)
+	}
+
+	nOutOf := envelope.GetRule().GetNOutOf()
+	if nOutOf == nil {
+		return nil, fmt.Errorf("endorsement policy is not an N out of organizations policy")
+	}
+
+	p := NewEndorsementPolicy()
+	for _, rule := range nOutOf.GetRules() {
+		signedBy, ok := rule.GetType().(*common.SignaturePolicy_SignedBy)
+		if !ok {
+			return nil, fmt.Errorf("endorsement policy has nested rules, which are not supported")
+		}
+		if signedBy.SignedBy < 0 || int(signedBy.SignedBy) >= len(envelope.GetIdentities()) {
+			return nil, fmt.Errorf("endorsement policy refers to unknown identity %d", signedBy.SignedBy)
+		}
+
+		principal := envelope.GetIdentities()[signedBy.SignedBy]
+		if principal.GetPrincipalClassification() != msp.MSPPrincipal_ROLE {
+			return nil, fmt.Errorf("endorsement policy has a principal that is not a role")
+		}
+		role := &msp.MSPRole{}
+		if err := proto.Unmarshal(principal.GetPrincipal(), role); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal endorsement policy principal: %v", err)
+		}
+		roleType, err := roleTypeOf(role.GetRole())
+		if err != nil {
+			return nil, err
+		}
+		p.orgs[role.GetMspIdentifier()] = roleType
+	}
+
+	if int(nOutOf.GetN()) != len(p.orgs) {
+		p.threshold = int(nOutOf.GetN())
+	}
+	return p, nil
+}
+
+// AddOrgs adds organizations to the policy. Their endorsing identities must have the given role. Adding an
+// organization that is already in the policy replaces its role.
+//
+// Parameters:
+//   - role: The role the endorsing identities must have.
+//   - mspIDs: The MSP IDs of the organizations.
+//
+// Returns:
+//   - error: An error if the role type is unknown or an MSP ID is empty.
+func (p *EndorsementPolicy) AddOrgs(role RoleType, mspIDs ...string) error {
+	if _, ok := mspRoles[role]; !ok {
+		return fmt.Errorf("unknown role type %s", role)
+	}
+	for _, mspID := range mspIDs {
+		if mspID == "" {
+			return fmt.Errorf("MSP ID must not be empty")
+		}
+	}
+	for _, mspID := range mspIDs {
+		p.orgs[mspID] = role
+	}
+	return nil
+}
+
+// DelOrgs removes organizations from the policy. Organizations that are not in the policy are ignored.
+//
+// Parameters:
+//   - mspIDs: The MSP IDs of the organizations.
+func (p *EndorsementPolicy) DelOrgs(mspIDs ...string) {
+	for _, mspID := range mspIDs {
+		delete(p.orgs, mspID)
+	}
+}
+
+// ListOrgs returns the MSP IDs of the organizations in the policy, sorted.
+//
+// Returns:
+//   - []string: The MSP IDs.
+func (p *EndorsementPolicy) ListOrgs() []string {
+	mspIDs := make([]string, 0, len(p.orgs))
+	for mspID := range p.orgs {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	return mspIDs
+}
+
+// Role returns the role required of the endorsing identities of an organization.
+//
+// Parameters:
+//   - mspID: The MSP ID of the organization.
+//
+// Returns:
+//   - RoleType: The required role.
+//   - bool: False if the organization is not in the policy.
+func (p *EndorsementPolicy) Role(mspID string) (RoleType, bool) {
+	role, ok := p.orgs[mspID]
+	return role, ok
+}
+
+// SetThreshold sets the number of organizations that must endorse. Zero requires every organization.
+//
+// Parameters:
+//   - n: The number of organizations that must endorse.
+//
+// Returns:
+//   - error: An error if n is negative.
+func (p *EndorsementPolicy) SetThreshold(n int) error {
+	if n < 0 {
+		return fmt.Errorf("endorsement threshold must not be negative, got %d", n)
+	}
+	p.threshold = n
+	return nil
+}
+
+// Threshold returns the number of organizations that must endorse.
+//
+// Returns:
+//   - int: The threshold, or the number of organizations if no threshold is set.
+func (p *EndorsementPolicy) Threshold() int {
+	if p.threshold == 0 {
+		return len(p.orgs)
+	}
+	return p.threshold
+}
+
+// Policy serializes the policy to a Fabric SignaturePolicyEnvelope, the format expected by
+// SetStateValidationParameter. The organizations are listed in the order of their MSP IDs, so the same policy
+// always serializes to the same bytes.
+//
+// Returns:
+//   - []byte: The serialized SignaturePolicyEnvelope.
+//   - error: An error if the policy has no organizations or the threshold exceeds their number.
+func (p *EndorsementPolicy) Policy() ([]byte, error) {
+	if len(p.orgs) == 0 {
+		return nil, fmt.Errorf("endorsement policy has no organizations")
+	}
+	if p.threshold > len(p.orgs) {
+		return nil, fmt.Errorf("endorsement threshold %d exceeds the %d organizations of the policy", p.threshold, len(p.orgs))
+	}
+
+	mspIDs := p.ListOrgs()
+	principals := make([]*msp.MSPPrincipal, 0, len(mspIDs))
+	rules := make([]*common.SignaturePolicy, 0, len(mspIDs))
+	for i, mspID := range mspIDs {
+		role, err := proto.Marshal(&msp.MSPRole{Role: mspRoles[p.orgs[mspID]], MspIdentifier: mspID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal role of %s: %v", mspID, err)
+		}
+		principals = append(principals, &msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: role})
+		rules = append(rules, &common.SignaturePolicy{Type: &common.SignaturePolicy_SignedBy{SignedBy: int32(i)}})
+	}
+
+	envelope := &common.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule: &common.SignaturePolicy{Type: &common.SignaturePolicy_NOutOf_{
+			NOutOf: &common.SignaturePolicy_NOutOf{N: int32(p.Threshold()), Rules: rules},
+		}},
+		Identities: principals,
+	}
+	policy, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal endorsement policy: %v", err)
+	}
+	return policy, nil
+}
+
+// roleTypeOf returns the role type of an MSP role.
+func roleTypeOf(role msp.MSPRole_MSPRoleType) (RoleType, error) {
+	for roleType, mspRole := range mspRoles {
+		if mspRole == role {
+			return roleType, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported MSP role %s in endorsement policy", role)
+}
+
+// SetStateValidationParameter sets the key-level endorsement policy of `key`, serialized as a
+// SignaturePolicyEnvelope. The policy takes effect for the transactions after this one is committed.
+//
+// Parameters:
+//   - key: The key of the state.
+//   - ep: The serialized endorsement policy.
+//
+// Returns:
+//   - error: An error if the policy could not be set.
+func (ctx *TransactionContext) SetStateValidationParameter(key string, ep []byte) error {
+	return ctx.GetStub().SetStateValidationParameter(key, ep)
+}
+
+// GetStateValidationParameter returns the serialized key-level endorsement policy of `key`.
+//
+// Parameters:
+//   - key: The key of the state.
+//
+// Returns:
+//   - []byte: The serialized endorsement policy, or nil if none is set.
+//   - error: An error if the policy could not be read.
+func (ctx *TransactionContext) GetStateValidationParameter(key string) ([]byte, error) {
+	return ctx.GetStub().GetStateValidationParameter(key)
+}
+
+// SetPrivateDataValidationParameter sets the key-level endorsement policy of `key` in the private data
+// `collection`, serialized as a SignaturePolicyEnvelope.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//   - ep: The serialized endorsement policy.
+//
+// Returns:
+//   - error: An error if the policy could not be set.
+func (ctx *TransactionContext) SetPrivateDataValidationParameter(collection string, key string, ep []byte) error {
+	return ctx.GetStub().SetPrivateDataValidationParameter(collection, key, ep)
+}
+
+// GetPrivateDataValidationParameter returns the serialized key-level endorsement policy of `key` in the private
+// data `collection`.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//
+// Returns:
+//   - []byte: The serialized endorsement policy, or nil if none is set.
+//   - error: An error if the policy could not be read.
+func (ctx *TransactionContext) GetPrivateDataValidationParameter(collection string, key string) ([]byte, error) {
+	return ctx.GetStub().GetPrivateDataValidationParameter(collection, key)
+}
+
+// SetStateEndorsementPolicy sets the key-level endorsement policy of `key`, so that changes to the key must be
+// endorsed by the organizations of the policy.
+//
+// Parameters:
+//   - key: The key of the state.
+//   - policy: The endorsement policy.
+//
+// Returns:
+//   - error: An error if the policy is invalid or could not be set.
+func (ctx *TransactionContext) SetStateEndorsementPolicy(key string, policy *EndorsementPolicy) error {
+	ep, err := policy.Policy()
+	if err != nil {
+		return err
+	}
+	return ctx.SetStateValidationParameter(key, ep)
+}
+
+// GetStateEndorsementPolicy returns the key-level endorsement policy of `key`.
+//
+// Parameters:
+//   - key: The key of the state.
+//
+// Returns:
+//   - *EndorsementPolicy: The endorsement policy, or nil if none is set.
+//   - error: An error if the policy could not be read or decoded.
+func (ctx *TransactionContext) GetStateEndorsementPolicy(key string) (*EndorsementPolicy, error) {
+	ep, err := ctx.GetStateValidationParameter(key)
+	if err != nil || ep == nil {
+		return nil, err
+	}
+	return ParseEndorsementPolicy(ep)
+}
+
+// SetPrivateDataEndorsementPolicy sets the key-level endorsement policy of `key` in the private data `collection`.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//   - policy: The endorsement policy.
+//
+// Returns:
+//   - error: An error if the policy is invalid or could not be set.
+func (ctx *TransactionContext) SetPrivateDataEndorsementPolicy(collection string, key string, policy *EndorsementPolicy) error {
+	ep, err := policy.Policy()
+	if err != nil {
+		return err
+	}
+	return ctx.SetPrivateDataValidationParameter(collection, key, ep)
+}
+
+// GetPrivateDataEndorsementPolicy returns the key-level endorsement policy of `key` in the private data
+// `collection`.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//
+// Returns:
+//   - *EndorsementPolicy: The endorsement policy, or nil if none is set.
+//   - error: An error if the policy could not be read or decoded.
+func (ctx *TransactionContext) GetPrivateDataEndorsementPolicy(collection string, key string) (*EndorsementPolicy, error) {
+	ep, err := ctx.GetPrivateDataValidationParameter(collection, key)
+	if err != nil || ep == nil {
+		return nil, err
+	}
+	return ParseEndorsementPolicy(ep)
+}
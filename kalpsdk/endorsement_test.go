@@ -0,0 +1,133 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndorsementPolicy(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		policy := NewEndorsementPolicy()
+		require.NoError(t, policy.AddOrgs(RoleTypePeer, "Org2MSP", "Org1MSP", "Org3MSP"))
+		require.NoError(t, policy.AddOrgs(RoleTypeAdmin, "Org3MSP"))
+		require.NoError(t, policy.SetThreshold(2))
+		require.Equal(t, []string{"Org1MSP", "Org2MSP", "Org3MSP"}, policy.ListOrgs())
+
+		ep, err := policy.Policy()
+		require.NoError(t, err)
+
+		envelope := &common.SignaturePolicyEnvelope{}
+		require.NoError(t, proto.Unmarshal(ep, envelope))
+		require.Equal(t, int32(2), envelope.GetRule().GetNOutOf().GetN())
+		require.Len(t, envelope.GetRule().GetNOutOf().GetRules(), 3)
+		require.Len(t, envelope.GetIdentities(), 3)
+
+		role := &msp.MSPRole{}
+		require.NoError(t, proto.Unmarshal(envelope.GetIdentities()[2].GetPrincipal(), role))
+		require.Equal(t, "Org3MSP", role.GetMspIdentifier())
+		require.Equal(t, msp.MSPRole_ADMIN, role.GetRole())
+
+		// Check that the policy survives a round trip
+		parsed, err := ParseEndorsementPolicy(ep)
+		require.NoError(t, err)
+		require.Equal(t, policy, parsed)
+	})
+
+	// Check that all organizations must endorse without a threshold
+	t.Run("Check for default threshold", func(t *testing.T) {
+		policy := NewEndorsementPolicy()
+		require.NoError(t, policy.AddOrgs(RoleTypeMember, "Org1MSP", "Org2MSP"))
+		require.Equal(t, 2, policy.Threshold())
+
+		policy.DelOrgs("Org2MSP", "Org9MSP")
+		require.Equal(t, []string{"Org1MSP"}, policy.ListOrgs())
+		role, ok := policy.Role("Org1MSP")
+		require.True(t, ok)
+		require.Equal(t, RoleTypeMember, role)
+
+		ep, err := policy.Policy()
+		require.NoError(t, err)
+		parsed, err := ParseEndorsementPolicy(ep)
+		require.NoError(t, err)
+		require.Equal(t, 1, parsed.Threshold())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		policy := NewEndorsementPolicy()
+		require.EqualError(t, policy.AddOrgs(RoleType("AUDITOR"), "Org1MSP"), "unknown role type AUDITOR")
+		require.EqualError(t, policy.AddOrgs(RoleTypePeer, ""), "MSP ID must not be empty")
+		require.EqualError(t, policy.SetThreshold(-1), "endorsement threshold must not be negative, got -1")
+
+		_, err := policy.Policy()
+		require.EqualError(t, err, "endorsement policy has no organizations")
+
+		require.NoError(t, policy.AddOrgs(RoleTypePeer, "Org1MSP"))
+		require.NoError(t, policy.SetThreshold(2))
+		_, err = policy.Policy()
+		require.EqualError(t, err, "endorsement threshold 2 exceeds the 1 organizations of the policy")
+
+		_, err = ParseEndorsementPolicy([]byte("not a policy"))
+		require.ErrorContains(t, err, "failed to unmarshal endorsement policy")
+
+		signedBy, err := proto.Marshal(&common.SignaturePolicyEnvelope{Rule: &common.SignaturePolicy{Type: &common.SignaturePolicy_SignedBy{SignedBy: 0}}})
+		require.NoError(t, err)
+		_, err = ParseEndorsementPolicy(signedBy)
+		require.EqualError(t, err, "endorsement policy is not an N out of organizations policy")
+	})
+}
+
+func TestStateEndorsementPolicy(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+	policy := NewEndorsementPolicy()
+	require.NoError(t, policy.AddOrgs(RoleTypePeer, "Org1MSP", "Org2MSP"))
+	ep, err := policy.Policy()
+	require.NoError(t, err)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("SetStateValidationParameter", "asset1", ep).Return(nil).Once()
+		mockStub.On("GetStateValidationParameter", "asset1").Return(ep, nil).Once()
+		mockStub.On("SetPrivateDataValidationParameter", "collectionOrg1", "asset1", ep).Return(nil).Once()
+		mockStub.On("GetPrivateDataValidationParameter", "collectionOrg1", "asset1").Return(ep, nil).Once()
+
+		require.NoError(t, tx.SetStateEndorsementPolicy("asset1", policy))
+		got, err := tx.GetStateEndorsementPolicy("asset1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"Org1MSP", "Org2MSP"}, got.ListOrgs())
+
+		require.NoError(t, tx.SetPrivateDataEndorsementPolicy("collectionOrg1", "asset1", policy))
+		got, err = tx.GetPrivateDataEndorsementPolicy("collectionOrg1", "asset1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"Org1MSP", "Org2MSP"}, got.ListOrgs())
+	})
+
+	// Check that keys without a policy return nil
+	t.Run("Check for missing policy", func(t *testing.T) {
+		mockStub.On("GetStateValidationParameter", "asset2").Return(nil, nil).Once()
+
+		got, err := tx.GetStateEndorsementPolicy("asset2")
+		require.NoError(t, err)
+		require.Nil(t, got)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("SetStateValidationParameter", "asset1", ep).Return(fmt.Errorf("failed to set policy")).Once()
+
+		require.EqualError(t, tx.SetStateEndorsementPolicy("asset1", policy), "failed to set policy")
+		require.EqualError(t, tx.SetStateEndorsementPolicy("asset1", NewEndorsementPolicy()), "endorsement policy has no organizations")
+	})
+}
@@ -0,0 +1,350 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors describing why the identity of the submitting client could not be determined. They are wrapped in an
+// *IdentityError and can be matched with errors.Is.
+var (
+	ErrNoClientIdentity  = errors.New("no client identity in transaction context")
+	ErrMalformedClientID = errors.New("client ID is not of the form x509::<subject>::<issuer>")
+	ErrMalformedDN       = errors.New("malformed distinguished name")
+	ErrMissingCommonName = errors.New("subject has no common name")
+)
+
+const x509IDPrefix = "x509::"
+
+// IdentityError is returned when the identity of the submitting client could not be read or parsed.
+type IdentityError struct {
+	Op  string // The step that failed, e.g. "failed to read clientID".
+	Err error  // The cause, often one of the Err* identity errors.
+}
+
+// Error returns the failed step followed by its cause.
+func (e *IdentityError) Error() string {
+	return e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap returns the cause of the error.
+func (e *IdentityError) Unwrap() error {
+	return e.Err
+}
+
+// DNAttribute is a single type and value pair of a distinguished name, e.g. OU=client.
+type DNAttribute struct {
+	Type  string // The short name of the attribute type, e.g. "CN", or its OID if it has none.
+	Value string // The unescaped value.
+}
+
+// DistinguishedName is a parsed X.509 distinguished name.
+type DistinguishedName struct {
+	CommonName          string        // The first CN.
+	SerialNumber        string        // The first SERIALNUMBER.
+	OrganizationalUnits []string      // All OU values, in order.
+	Organizations       []string      // All O values, in order.
+	Countries           []string      // All C values, in order.
+	Localities          []string      // All L values, in order.
+	Provinces           []string      // All ST values, in order.
+	Attributes          []DNAttribute // All attributes, in the order they appear in the name.
+}
+
+// String returns the distinguished name in the format used by the client ID, e.g.
+// "CN=alice,OU=client,O=Org1". Multi-valued RDNs are written as separate RDNs.
+func (dn DistinguishedName) String() string {
+	rdns := make([]string, 0, len(dn.Attributes))
+	for _, attr := range dn.Attributes {
+		rdns = append(rdns, attr.Type+"="+escapeDNValue(attr.Value))
+	}
+	return strings.Join(rdns, ",")
+}
+
+// add records an attribute in the name and in its typed field.
+func (dn *DistinguishedName) add(attrType string, value string) {
+	dn.Attributes = append(dn.Attributes, DNAttribute{Type: attrType, Value: value})
+	switch attrType {
+	case "CN":
+		if dn.CommonName == "" {
+			dn.CommonName = value
+		}
+	case "SERIALNUMBER":
+		if dn.SerialNumber == "" {
+			dn.SerialNumber = value
+		}
+	case "OU":
+		dn.OrganizationalUnits = append(dn.OrganizationalUnits, value)
+	case "O":
+		dn.Organizations = append(dn.Organizations, value)
+	case "C":
+		dn.Countries = append(dn.Countries, value)
+	case "L":
+		dn.Localities = append(dn.Localities, value)
+	case "ST":
+		dn.Provinces = append(dn.Provinces, value)
+	}
+}
+
+// UserIdentity describes the client that submitted the transaction.
+type UserIdentity struct {
+	CommonName          string            // The CN of the certificate subject, as returned by GetUserID.
+	OrganizationalUnits []string          // The OU values of the certificate subject, e.g. "client".
+	Organization        string            // The first O value of the certificate subject.
+	MSPID               string            // The MSP the identity belongs to.
+	Subject             DistinguishedName // The full subject of the certificate.
+	Issuer              DistinguishedName // The issuer of the certificate, usually the Fabric CA.
+}
+
+// ParseDistinguishedName parses a distinguished name in the string format of the client ID, such as
+// "CN=alice,OU=client+OU=department1,O=Org1". Values may contain escaped special characters ("\,") and
+// hex escapes ("\2C"); values of unknown attribute types written as "#<hex DER>" are kept as they are.
+//
+// Parameters:
+//   - s: The distinguished name to parse.
+//
+// Returns:
+//   - DistinguishedName: The parsed name.
+//   - error: An error wrapping ErrMalformedDN if the name is malformed.
+func ParseDistinguishedName(s string) (DistinguishedName, error) {
+	var dn DistinguishedName
+	if s == "" {
+		return dn, fmt.Errorf("%w: empty name", ErrMalformedDN)
+	}
+
+	for _, typeAndValue := range splitDN(s) {
+		eq := strings.IndexByte(typeAndValue, '=')
+		if eq <= 0 {
+			return DistinguishedName{}, fmt.Errorf("%w: %q is not of the form type=value", ErrMalformedDN, typeAndValue)
+		}
+		attrType := strings.TrimSpace(typeAndValue[:eq])
+		if !isValidDNType(attrType) {
+			return DistinguishedName{}, fmt.Errorf("%w: invalid attribute type %q", ErrMalformedDN, attrType)
+		}
+		value, err := unescapeDNValue(typeAndValue[eq+1:])
+		if err != nil {
+			return DistinguishedName{}, err
+		}
+		dn.add(attrType, value)
+	}
+	return dn, nil
+}
+
+// splitDN splits a distinguished name into its still escaped type=value pairs at unescaped ',' and '+'
+// characters.
+func splitDN(s string) []string {
+	var components []string
+	begin := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // Skip the escaped character.
+		case ',', '+':
+			components = append(components, s[begin:i])
+			begin = i + 1
+		}
+	}
+	return append(components, s[begin:])
+}
+
+// unescapeDNValue removes the escaping of a distinguished name value.
+func unescapeDNValue(value string) (string, error) {
+	if !strings.Contains(value, "\\") {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i+1 >= len(value) {
+			return "", fmt.Errorf("%w: value %q ends with an escape character", ErrMalformedDN, value)
+		}
+		if i+2 < len(value) && isHexDigit(value[i+1]) && isHexDigit(value[i+2]) {
+			decoded, _ := hex.DecodeString(value[i+1 : i+3])
+			b.Write(decoded)
+			i += 2
+			continue
+		}
+		b.WriteByte(value[i+1])
+		i++
+	}
+	return b.String(), nil
+}
+
+// escapeDNValue escapes the characters of a distinguished name value the same way the client ID does.
+func escapeDNValue(value string) string {
+	var b strings.Builder
+	for i, c := range value {
+		if (i == 0 && (c == ' ' || c == '#')) || (i == len(value)-1 && c == ' ') {
+			b.WriteByte('\\')
+		} else {
+			switch c {
+			case ',', '+', '"', '\\', '<', '>', ';':
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// isValidDNType reports whether t is an attribute type keyword such as "CN" or a dotted OID such as "2.5.4.3".
+func isValidDNType(t string) bool {
+	if t == "" {
+		return false
+	}
+	isOID := t[0] >= '0' && t[0] <= '9'
+	for i := 0; i < len(t); i++ {
+		c := t[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case isOID && c == '.':
+		case !isOID && (('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c == '-'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isHexDigit reports whether c is a hexadecimal digit.
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// parseClientID splits a decoded client ID of the form "x509::<subject>::<issuer>" into its subject and issuer.
+// As the separator may also appear inside a value, the first split at which both halves parse is used.
+// An ID without an issuer is accepted with an empty issuer.
+func parseClientID(id string) (DistinguishedName, DistinguishedName, error) {
+	if !strings.HasPrefix(id, x509IDPrefix) {
+		return DistinguishedName{}, DistinguishedName{}, ErrMalformedClientID
+	}
+	rest := id[len(x509IDPrefix):]
+
+	for offset := 0; ; {
+		sep := strings.Index(rest[offset:], "::")
+		if sep < 0 {
+			break
+		}
+		sep += offset
+		subject, subjectErr := ParseDistinguishedName(rest[:sep])
+		issuer, issuerErr := ParseDistinguishedName(rest[sep+2:])
+		if subjectErr == nil && issuerErr == nil {
+			return subject, issuer, nil
+		}
+		offset = sep + 1
+	}
+
+	subject, err := ParseDistinguishedName(rest)
+	if err != nil {
+		return DistinguishedName{}, DistinguishedName{}, err
+	}
+	return subject, DistinguishedName{}, nil
+}
+
+// distinguishedNameFromPkix converts a certificate name into a DistinguishedName, listing the attributes in the
+// same order as the client ID.
+func distinguishedNameFromPkix(name pkix.Name) DistinguishedName {
+	var dn DistinguishedName
+	rdns := name.ToRDNSequence()
+	for i := len(rdns) - 1; i >= 0; i-- {
+		for _, tv := range rdns[i] {
+			attrType, ok := dnAttributeTypeNames[tv.Type.String()]
+			if !ok {
+				attrType = tv.Type.String()
+			}
+			dn.add(attrType, fmt.Sprint(tv.Value))
+		}
+	}
+	return dn
+}
+
+// dnAttributeTypeNames maps the OIDs of the attribute types written by name in the client ID to their names.
+var dnAttributeTypeNames = map[string]string{
+	"2.5.4.6":  "C",
+	"2.5.4.10": "O",
+	"2.5.4.11": "OU",
+	"2.5.4.3":  "CN",
+	"2.5.4.5":  "SERIALNUMBER",
+	"2.5.4.7":  "L",
+	"2.5.4.8":  "ST",
+	"2.5.4.9":  "STREET",
+	"2.5.4.17": "POSTALCODE",
+}
+
+// getClientIDNames reads the client ID and returns the parsed subject and issuer.
+func (ctx *TransactionContext) getClientIDNames() (DistinguishedName, DistinguishedName, error) {
+	ci := ctx.GetClientIdentity()
+	if ci == nil {
+		return DistinguishedName{}, DistinguishedName{}, &IdentityError{Op: "failed to read clientID", Err: ErrNoClientIdentity}
+	}
+
+	b64ID, err := ci.GetID()
+	if err != nil {
+		return DistinguishedName{}, DistinguishedName{}, &IdentityError{Op: "failed to read clientID", Err: err}
+	}
+
+	decodeID, err := base64.StdEncoding.DecodeString(b64ID)
+	if err != nil {
+		return DistinguishedName{}, DistinguishedName{}, &IdentityError{Op: "failed to base64 decode clientID", Err: err}
+	}
+
+	subject, issuer, err := parseClientID(string(decodeID))
+	if err != nil {
+		return DistinguishedName{}, DistinguishedName{}, &IdentityError{Op: "failed to parse clientID", Err: err}
+	}
+	return subject, issuer, nil
+}
+
+// GetUserIdentity returns the identity of the client that submitted the transaction. The subject and issuer are
+// taken from the client's X.509 certificate, or parsed from the client ID if the certificate is not available.
+//
+// Returns:
+//   - *UserIdentity: The common name, organizational units, organization, MSP ID, subject and issuer of the client.
+//   - error: An *IdentityError if the identity could not be read or has no common name.
+func (ctx *TransactionContext) GetUserIdentity() (*UserIdentity, error) {
+	ci := ctx.GetClientIdentity()
+	if ci == nil {
+		return nil, &IdentityError{Op: "failed to read client identity", Err: ErrNoClientIdentity}
+	}
+
+	mspID, err := ci.GetMSPID()
+	if err != nil {
+		return nil, &IdentityError{Op: "failed to read MSP ID", Err: err}
+	}
+
+	var subject, issuer DistinguishedName
+	cert, err := ci.GetX509Certificate()
+	if err == nil && cert != nil {
+		subject = distinguishedNameFromPkix(cert.Subject)
+		issuer = distinguishedNameFromPkix(cert.Issuer)
+	} else {
+		subject, issuer, err = ctx.getClientIDNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if subject.CommonName == "" {
+		return nil, &IdentityError{Op: "failed to read user identity", Err: ErrMissingCommonName}
+	}
+
+	identity := &UserIdentity{
+		CommonName:          subject.CommonName,
+		OrganizationalUnits: subject.OrganizationalUnits,
+		MSPID:               mspID,
+		Subject:             subject,
+		Issuer:              issuer,
+	}
+	if len(subject.Organizations) > 0 {
+		identity.Organization = subject.Organizations[0]
+	}
+	return identity, nil
+}
@@ -0,0 +1,164 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistinguishedName(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		dn, err := ParseDistinguishedName(`CN=alice,OU=client+OU=department1,O=Org1,C=IN`)
+		require.NoError(t, err)
+		require.Equal(t, "alice", dn.CommonName)
+		require.Equal(t, []string{"client", "department1"}, dn.OrganizationalUnits)
+		require.Equal(t, []string{"Org1"}, dn.Organizations)
+		require.Equal(t, []string{"IN"}, dn.Countries)
+		require.Len(t, dn.Attributes, 5)
+		require.Equal(t, `CN=alice,OU=client,OU=department1,O=Org1,C=IN`, dn.String())
+	})
+
+	// Check escaped values
+	t.Run("Check escaped values", func(t *testing.T) {
+		dn, err := ParseDistinguishedName(`OU=client,CN=Smith\, John\2B Co,O=Org\=1`)
+		require.NoError(t, err)
+		require.Equal(t, "Smith, John+ Co", dn.CommonName)
+		require.Equal(t, []string{"Org=1"}, dn.Organizations)
+		require.Equal(t, `OU=client,CN=Smith\, John\+ Co,O=Org=1`, dn.String())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		for _, name := range []string{``, `CN=alice,123`, `=alice`, `CN=alice\`} {
+			_, err := ParseDistinguishedName(name)
+			require.True(t, errors.Is(err, ErrMalformedDN), name)
+		}
+	})
+}
+
+func TestGetUserIDParsing(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+		err      error
+	}{
+		{"Check CN without comma", "x509::CN=alice::CN=ca.org1.example.com", "alice", nil},
+		{"Check CN not first", "x509::OU=client,CN=alice,O=Org1::CN=ca", "alice", nil},
+		{"Check escaped comma", `x509::CN=Smith\, John,OU=client::CN=ca`, "Smith, John", nil},
+		{"Check separator inside value", "x509::CN=a::b,OU=client::CN=ca", "a::b", nil},
+		{"Check missing issuer", "x509::CN=alice,OU=client", "alice", nil},
+		{"Check missing prefix", "CN=alice::CN=ca", "", ErrMalformedClientID},
+		{"Check malformed subject", "x509::alice::CN=ca", "", ErrMalformedDN},
+		{"Check missing CN", "x509::OU=client::CN=ca", "", ErrMissingCommonName},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClientIdentity := new(mocks.ClientIdentity)
+			ctx := &TransactionContext{
+				clientIdentity: mockClientIdentity,
+			}
+			mockClientIdentity.On("GetID").Return(base64.StdEncoding.EncodeToString([]byte(tc.id)), nil)
+
+			userID, err := ctx.GetUserID()
+			if tc.err != nil {
+				var identityErr *IdentityError
+				require.True(t, errors.As(err, &identityErr))
+				require.True(t, errors.Is(err, tc.err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, userID)
+		})
+	}
+
+	// Check that a context without client identity does not panic
+	t.Run("Check for missing client identity", func(t *testing.T) {
+		_, err := (&TransactionContext{}).GetUserID()
+		require.True(t, errors.Is(err, ErrNoClientIdentity))
+
+		_, err = (&TransactionContext{}).GetUserIdentity()
+		require.True(t, errors.Is(err, ErrNoClientIdentity))
+	})
+
+	// Check that an ID which is not base64 is reported
+	t.Run("Check for malformed base64", func(t *testing.T) {
+		mockClientIdentity := new(mocks.ClientIdentity)
+		ctx := &TransactionContext{
+			clientIdentity: mockClientIdentity,
+		}
+		mockClientIdentity.On("GetID").Return("not base64!", nil)
+
+		_, err := ctx.GetUserID()
+		require.ErrorContains(t, err, "failed to base64 decode clientID")
+	})
+}
+
+func TestGetUserIdentity(t *testing.T) {
+	// Check the identity is read from the certificate
+	t.Run("Check for certificate", func(t *testing.T) {
+		mockClientIdentity := new(mocks.ClientIdentity)
+		ctx := &TransactionContext{
+			clientIdentity: mockClientIdentity,
+		}
+		cert := &x509.Certificate{
+			Subject: pkix.Name{CommonName: "alice", OrganizationalUnit: []string{"client"}, Organization: []string{"Org1"}},
+			Issuer:  pkix.Name{CommonName: "ca.org1.example.com", Organization: []string{"org1.example.com"}},
+		}
+		mockClientIdentity.On("GetMSPID").Return("Org1MSP", nil)
+		mockClientIdentity.On("GetX509Certificate").Return(cert, nil)
+
+		identity, err := ctx.GetUserIdentity()
+		require.NoError(t, err)
+		require.Equal(t, "alice", identity.CommonName)
+		require.Equal(t, []string{"client"}, identity.OrganizationalUnits)
+		require.Equal(t, "Org1", identity.Organization)
+		require.Equal(t, "Org1MSP", identity.MSPID)
+		require.Equal(t, "CN=ca.org1.example.com,O=org1.example.com", identity.Issuer.String())
+	})
+
+	// Check the identity is parsed from the client ID without certificate
+	t.Run("Check for client ID", func(t *testing.T) {
+		mockClientIdentity := new(mocks.ClientIdentity)
+		ctx := &TransactionContext{
+			clientIdentity: mockClientIdentity,
+		}
+		mockClientIdentity.On("GetMSPID").Return("Org1MSP", nil)
+		mockClientIdentity.On("GetX509Certificate").Return(nil, nil)
+		mockClientIdentity.On("GetID").Return(base64.StdEncoding.EncodeToString([]byte("x509::CN=bob,OU=client+OU=department1,O=Org1::CN=ca")), nil)
+
+		identity, err := ctx.GetUserIdentity()
+		require.NoError(t, err)
+		require.Equal(t, &UserIdentity{
+			CommonName:          "bob",
+			OrganizationalUnits: []string{"client", "department1"},
+			Organization:        "Org1",
+			MSPID:               "Org1MSP",
+			Subject:             identity.Subject,
+			Issuer:              identity.Issuer,
+		}, identity)
+		require.Equal(t, "ca", identity.Issuer.CommonName)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockClientIdentity := new(mocks.ClientIdentity)
+		ctx := &TransactionContext{
+			clientIdentity: mockClientIdentity,
+		}
+		mockClientIdentity.On("GetMSPID").Return("", fmt.Errorf("no MSP"))
+
+		_, err := ctx.GetUserIdentity()
+		require.EqualError(t, err, "failed to read MSP ID: no MSP")
+	})
+}
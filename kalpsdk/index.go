@@ -0,0 +1,254 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"sort"
+)
+
+// indexEntryValue is the value stored under an index entry. The entry itself is the composite key; a nil value
+// would delete it, so a single null byte is stored instead.
+var indexEntryValue = []byte{0x00}
+
+// Index is a secondary index of the records of a Repository, stored as composite keys in the world state. Unlike
+// rich queries, lookups through an index are range queries on composite keys, so the peer re-validates them for
+// phantom reads when the transaction is committed.
+//
+// For every record, Keys returns the attribute tuples the record is indexed under. The repository stores one entry
+// per tuple under the composite key (IndexObjectType, tuple..., record key), and deletes the stale entries when the
+// record is updated or deleted.
+type Index[T any] struct {
+	Name string                     // The name of the index, e.g. "account".
+	Keys func(record *T) [][]string // The attribute tuples the record is indexed under; none if the record is not indexed.
+}
+
+// IndexBy returns an index of the records under a single attribute.
+//
+// Parameters:
+//   - name: The name of the index.
+//   - key: Returns the values the record is indexed under, e.g. all its owners.
+//
+// Returns:
+//   - Index[T]: The index.
+func IndexBy[T any](name string, key func(record *T) []string) Index[T] {
+	return Index[T]{Name: name, Keys: func(record *T) [][]string {
+		values := key(record)
+		keys := make([][]string, 0, len(values))
+		for _, value := range values {
+			keys = append(keys, []string{value})
+		}
+		return keys
+	}}
+}
+
+// WithIndex returns a copy of the repository that maintains the given index. Records written before the index was
+// added are only indexed once they are written again.
+//
+// Parameters:
+//   - index: The index to maintain.
+//
+// Returns:
+//   - *Repository[T]: The repository copy.
+func (r *Repository[T]) WithIndex(index Index[T]) *Repository[T] {
+	copied := *r
+	copied.indexes = append(append([]Index[T]{}, r.indexes...), index)
+	return &copied
+}
+
+// IndexObjectType returns the object type of the composite keys of the named index, "<docType>~<name>".
+//
+// Parameters:
+//   - name: The name of the index.
+//
+// Returns:
+//   - string: The object type of the index entries.
+func (r *Repository[T]) IndexObjectType(name string) string {
+	return r.docType + "~" + name
+}
+
+// FindKeysBy returns the keys of the records indexed under the given leading attribute values of the named index.
+//
+// Parameters:
+//   - name: The name of the index.
+//   - values: The leading attribute values to match; none matches every entry of the index.
+//
+// Returns:
+//   - []string: The keys of the matching records, in index order.
+//   - error: An error if the index does not exist or the query fails.
+func (r *Repository[T]) FindKeysBy(name string, values ...string) ([]string, error) {
+	if _, err := r.index(name); err != nil {
+		return nil, err
+	}
+
+	iterator, err := r.ctx.GetStateByPartialCompositeKey(r.IndexObjectType(name), values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index %s of %s: %v", name, r.docType, err)
+	}
+	return r.recordKeys(iterator)
+}
+
+// FindBy returns the records indexed under the given leading attribute values of the named index.
+//
+// Parameters:
+//   - name: The name of the index.
+//   - values: The leading attribute values to match; none matches every entry of the index.
+//
+// Returns:
+//   - []T: The matching records, in index order.
+//   - error: An error if the index does not exist, the query fails or an indexed record is missing.
+func (r *Repository[T]) FindBy(name string, values ...string) ([]T, error) {
+	keys, err := r.FindKeysBy(name, values...)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(keys)
+}
+
+// FindPageBy returns a page of at most `pageSize` records indexed under the given leading attribute values of the
+// named index, starting at `bookmark`. Paginated queries are only allowed in read-only transactions.
+//
+// Parameters:
+//   - name: The name of the index.
+//   - pageSize: The maximum number of records to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//   - values: The leading attribute values to match; none matches every entry of the index.
+//
+// Returns:
+//   - *Page[T]: The page of matching records.
+//   - error: An error if the index does not exist, the query fails or an indexed record is missing.
+func (r *Repository[T]) FindPageBy(name string, pageSize int32, bookmark string, values ...string) (*Page[T], error) {
+	if _, err := r.index(name); err != nil {
+		return nil, err
+	}
+
+	iterator, metadata, err := r.ctx.GetStateByPartialCompositeKeyWithPagination(r.IndexObjectType(name), values, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query index %s of %s: %v", name, r.docType, err)
+	}
+	keys, err := r.recordKeys(iterator)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := r.resolve(keys)
+	if err != nil {
+		return nil, err
+	}
+	page := &Page[T]{Records: records}
+	if metadata != nil {
+		page.FetchedRecordsCount = metadata.FetchedRecordsCount
+		page.Bookmark = metadata.Bookmark
+	}
+	return page, nil
+}
+
+// index returns the named index of the repository.
+func (r *Repository[T]) index(name string) (Index[T], error) {
+	for _, index := range r.indexes {
+		if index.Name == name {
+			return index, nil
+		}
+	}
+	return Index[T]{}, fmt.Errorf("index %s is not defined for %s", name, r.docType)
+}
+
+// recordKeys returns the record keys of the index entries of the iterator, and closes it.
+func (r *Repository[T]) recordKeys(iterator StateQueryIteratorInterface) ([]string, error) {
+	defer iterator.Close()
+
+	var keys []string
+	for iterator.HasNext() {
+		entry, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		key, err := r.recordKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// recordKey returns the record key held by the last attribute of an index entry.
+func (r *Repository[T]) recordKey(compositeKey string) (string, error) {
+	_, attributes, err := r.ctx.SplitCompositeKey(compositeKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to split index entry %q: %v", compositeKey, err)
+	}
+	if len(attributes) == 0 {
+		return "", fmt.Errorf("index entry %q has no record key", compositeKey)
+	}
+	return attributes[len(attributes)-1], nil
+}
+
+// resolve reads the records stored under the given keys.
+func (r *Repository[T]) resolve(keys []string) ([]T, error) {
+	records := make([]T, 0, len(keys))
+	for _, key := range keys {
+		record, err := r.MustGet(key)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+// updateIndexEntries deletes the index entries of the previous record that the new record does not have, and
+// puts the entries of the new record that the previous one did not have. A nil record has no entries.
+func (r *Repository[T]) updateIndexEntries(key string, previous *T, record *T) error {
+	for _, index := range r.indexes {
+		stale, err := r.indexEntries(index, key, previous)
+		if err != nil {
+			return err
+		}
+		current, err := r.indexEntries(index, key, record)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range sortedEntries(stale) {
+			if !current[entry] {
+				if err := r.del(entry); err != nil {
+					return fmt.Errorf("failed to delete index entry of %s %s: %v", r.docType, key, err)
+				}
+			}
+		}
+		for _, entry := range sortedEntries(current) {
+			if !stale[entry] {
+				if err := r.write(entry, indexEntryValue); err != nil {
+					return fmt.Errorf("failed to put index entry of %s %s: %v", r.docType, key, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// indexEntries returns the composite keys the record is indexed under.
+func (r *Repository[T]) indexEntries(index Index[T], key string, record *T) (map[string]bool, error) {
+	entries := map[string]bool{}
+	if record == nil {
+		return entries, nil
+	}
+	for _, attributes := range index.Keys(record) {
+		entry, err := r.ctx.CreateCompositeKey(r.IndexObjectType(index.Name), append(append([]string{}, attributes...), key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create index entry of %s %s: %v", r.docType, key, err)
+		}
+		entries[entry] = true
+	}
+	return entries, nil
+}
+
+// sortedEntries returns the entries in a deterministic order, so that every endorser produces the same writes.
+func sortedEntries(entries map[string]bool) []string {
+	sorted := make([]string, 0, len(entries))
+	for entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
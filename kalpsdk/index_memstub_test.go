@@ -0,0 +1,79 @@
+package kalpsdk_test
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/kalptest"
+)
+
+type ownedAsset struct {
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	Owner   string `json:"owner"`
+}
+
+func TestRepositoryIndexesInOneTransaction(t *testing.T) {
+	stub := kalptest.NewMemStub("kalp")
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetStub(stub)
+	// A repository is created per transaction, as contract functions do
+	newRepository := func() *kalpsdk.Repository[ownedAsset] {
+		return kalpsdk.NewRepository[ownedAsset](ctx, "asset").WithoutKYC().
+			WithIndex(kalpsdk.IndexBy("owner", func(asset *ownedAsset) []string { return []string{asset.Owner} }))
+	}
+	owners := func(repo *kalpsdk.Repository[ownedAsset], owner string) []string {
+		keys, err := repo.FindKeysBy("owner", owner)
+		require.NoError(t, err)
+		return keys
+	}
+
+	stub.StartTransaction("tx1")
+	require.NoError(t, newRepository().Create("a1", &ownedAsset{DocType: "asset", ID: "a1", Owner: "alice"}))
+	stub.CommitTransaction()
+
+	// Check that only the index entries of the last write of a transaction remain
+	t.Run("Check for success response", func(t *testing.T) {
+		stub.StartTransaction("tx2")
+		repo := newRepository()
+		require.NoError(t, repo.Update("a1", &ownedAsset{DocType: "asset", ID: "a1", Owner: "bob"}))
+		require.NoError(t, repo.Update("a1", &ownedAsset{DocType: "asset", ID: "a1", Owner: "carol"}))
+		require.NoError(t, repo.Create("a2", &ownedAsset{DocType: "asset", ID: "a2", Owner: "bob"}))
+		require.NoError(t, repo.Upsert("a2", &ownedAsset{DocType: "asset", ID: "a2", Owner: "carol"}))
+		stub.CommitTransaction()
+
+		repo = newRepository()
+		require.Empty(t, owners(repo, "alice"))
+		require.Empty(t, owners(repo, "bob"))
+		require.Equal(t, []string{"a1", "a2"}, owners(repo, "carol"))
+
+		assets, err := repo.FindBy("owner", "carol")
+		require.NoError(t, err)
+		require.Equal(t, []ownedAsset{{DocType: "asset", ID: "a1", Owner: "carol"}, {DocType: "asset", ID: "a2", Owner: "carol"}}, assets)
+
+		stub.StartTransaction("tx3")
+		repo = newRepository()
+		require.NoError(t, repo.Update("a1", &ownedAsset{DocType: "asset", ID: "a1", Owner: "dave"}))
+		require.NoError(t, repo.Delete("a1"))
+		stub.CommitTransaction()
+
+		require.Empty(t, owners(newRepository(), "dave"))
+		require.Equal(t, []string{"a2"}, owners(newRepository(), "carol"))
+	})
+
+	// Check that the writes see the records created and deleted earlier in the transaction
+	t.Run("Check for failure response", func(t *testing.T) {
+		stub.StartTransaction("tx4")
+		repo := newRepository()
+		require.NoError(t, repo.Create("a3", &ownedAsset{DocType: "asset", ID: "a3", Owner: "erin"}))
+		require.ErrorIs(t, repo.Create("a3", &ownedAsset{DocType: "asset", ID: "a3", Owner: "frank"}), kalpsdk.ErrAlreadyExists)
+		require.NoError(t, repo.Delete("a2"))
+		require.ErrorIs(t, repo.Update("a2", &ownedAsset{DocType: "asset", ID: "a2", Owner: "frank"}), kalpsdk.ErrNotFound)
+		stub.AbortTransaction()
+	})
+}
@@ -0,0 +1,68 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryIndexes(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	repo := NewRepository[testAsset](&TransactionContext{stub: mockStub}, "asset").WithoutKYC().
+		WithIndex(IndexBy("owner", func(asset *testAsset) []string { return []string{asset.Owner} }))
+	mockStub.On("CreateCompositeKey", "asset~owner", []string{"alice", "a1"}).Return("\x00asset~owner\x00alice\x00a1\x00", nil)
+	mockStub.On("CreateCompositeKey", "asset~owner", []string{"bob", "a1"}).Return("\x00asset~owner\x00bob\x00a1\x00", nil)
+
+	// Check that stale index entries are replaced on update and removed on delete
+	t.Run("Check for index maintenance", func(t *testing.T) {
+		mockStub.On("GetState", "a1").Return([]byte(`{"docType":"asset","id":"a1","owner":"alice"}`), nil).Once()
+		mockStub.On("PutState", "a1", []byte(`{"docType":"asset","id":"a1","owner":"bob"}`)).Return(nil).Once()
+		mockStub.On("DelState", "\x00asset~owner\x00alice\x00a1\x00").Return(nil).Once()
+		mockStub.On("PutState", "\x00asset~owner\x00bob\x00a1\x00", []byte{0x00}).Return(nil).Once()
+		require.NoError(t, repo.Update("a1", &testAsset{DocType: "asset", ID: "a1", Owner: "bob"}))
+
+		// The second write of the transaction replaces the entries of the first one, not of the committed record
+		mockStub.On("PutState", "a1", []byte(`{"docType":"asset","id":"a1","owner":"alice"}`)).Return(nil).Once()
+		mockStub.On("DelState", "\x00asset~owner\x00bob\x00a1\x00").Return(nil).Once()
+		mockStub.On("PutState", "\x00asset~owner\x00alice\x00a1\x00", []byte{0x00}).Return(nil).Once()
+		require.NoError(t, repo.Upsert("a1", &testAsset{DocType: "asset", ID: "a1", Owner: "alice"}))
+
+		mockStub.On("DelState", "a1").Return(nil).Once()
+		mockStub.On("DelState", "\x00asset~owner\x00alice\x00a1\x00").Return(nil).Once()
+		require.NoError(t, repo.Delete("a1"))
+		mockStub.AssertExpectations(t)
+
+		// A record deleted in the transaction no longer exists for the writes
+		require.ErrorIs(t, repo.Update("a1", &testAsset{DocType: "asset", ID: "a1", Owner: "bob"}), ErrNotFound)
+	})
+
+	// Check that lookups resolve the index entries to records
+	t.Run("Check for success response", func(t *testing.T) {
+		mockState := new(mocks.StateQueryIteratorInterface)
+		mockState.On("HasNext").Return(true).Once()
+		mockState.On("HasNext").Return(false).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: "\x00asset~owner\x00bob\x00a1\x00", Value: []byte{0x00}}, nil).Once()
+		mockState.On("Close").Return(nil).Once()
+		mockStub.On("GetStateByPartialCompositeKey", "asset~owner", []string{"bob"}).Return(mockState, nil).Once()
+		mockStub.On("SplitCompositeKey", "\x00asset~owner\x00bob\x00a1\x00").Return("asset~owner", []string{"bob", "a1"}, nil).Once()
+		mockStub.On("GetState", "a1").Return([]byte(`{"docType":"asset","id":"a1","owner":"bob"}`), nil).Once()
+
+		assets, err := repo.FindBy("owner", "bob")
+		require.NoError(t, err)
+		require.Equal(t, []testAsset{{DocType: "asset", ID: "a1", Owner: "bob"}}, assets)
+		mockState.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := repo.FindBy("status", "COMPLETED")
+		require.EqualError(t, err, "index status is not defined for asset")
+
+		_, err = repo.FindPageBy("status", 10, "", "COMPLETED")
+		require.EqualError(t, err, "index status is not defined for asset")
+	})
+}
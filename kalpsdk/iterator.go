@@ -1,6 +1,12 @@
 package kalpsdk
 
 import (
+	//Standard Libs
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
 	//Third party Libs
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 )
@@ -15,3 +21,222 @@ type StateQueryIteratorInterface interface {
 type HistoryQueryIteratorInterface interface {
 	shim.HistoryQueryIteratorInterface
 }
+
+// ErrStopIteration is returned by the callback of ForEach or ForEachHistory to stop the iteration early. It is not
+// returned to the caller.
+var ErrStopIteration = errors.New("stop iteration")
+
+// HistoryEntry is a typed modification of a key returned by a history query.
+type HistoryEntry[T any] struct {
+	TxID      string    `json:"txId"`      // The ID of the transaction that modified the key.
+	Timestamp time.Time `json:"timestamp"` // The timestamp of the transaction.
+	IsDelete  bool      `json:"isDelete"`  // True if the transaction deleted the key.
+	Record    *T        `json:"record"`    // The value written by the transaction, nil if it deleted the key.
+}
+
+// ForEach decodes the JSON value of every key/value pair of the iterator into T and calls fn with the key and the
+// record, until fn returns an error or the iterator is exhausted. The iterator is closed when ForEach returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//   - fn: Called for every record. Returning ErrStopIteration stops the iteration without an error.
+//
+// Returns:
+//   - error: An error if the iterator fails, a value could not be decoded into T, or fn returns an error other than
+//     ErrStopIteration.
+func ForEach[T any](iterator StateQueryIteratorInterface, fn func(key string, record T) error) error {
+	defer iterator.Close()
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return err
+		}
+		var record T
+		if err := json.Unmarshal(kv.Value, &record); err != nil {
+			return fmt.Errorf("failed to unmarshal value of key %s: %v", kv.Key, err)
+		}
+		if err := fn(kv.Key, record); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Collect decodes the JSON value of every key/value pair of the iterator into T. The iterator is closed when
+// Collect returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//
+// Returns:
+//   - []T: The records, empty if the iterator has none.
+//   - error: An error if the iterator fails or a value could not be decoded into T.
+func Collect[T any](iterator StateQueryIteratorInterface) ([]T, error) {
+	records := []T{}
+	err := ForEach(iterator, func(key string, record T) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Map decodes the JSON value of every key/value pair of the iterator into T and returns the results of fn. The
+// iterator is closed when Map returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//   - fn: Converts a record. Returning ErrStopIteration stops the iteration, keeping the results so far.
+//
+// Returns:
+//   - []R: The converted records.
+//   - error: An error if the iterator fails, a value could not be decoded into T, or fn returns an error other than
+//     ErrStopIteration.
+func Map[T any, R any](iterator StateQueryIteratorInterface, fn func(key string, record T) (R, error)) ([]R, error) {
+	results := []R{}
+	err := ForEach(iterator, func(key string, record T) error {
+		result, err := fn(key, record)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Filter decodes the JSON value of every key/value pair of the iterator into T and returns the records for which
+// keep returns true. The iterator is closed when Filter returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//   - keep: Reports whether a record is returned.
+//
+// Returns:
+//   - []T: The kept records.
+//   - error: An error if the iterator fails or a value could not be decoded into T.
+func Filter[T any](iterator StateQueryIteratorInterface, keep func(key string, record T) bool) ([]T, error) {
+	records := []T{}
+	err := ForEach(iterator, func(key string, record T) error {
+		if keep(key, record) {
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// First decodes the JSON value of the first key/value pair of the iterator into T, without reading the others.
+// The iterator is closed when First returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//
+// Returns:
+//   - *T: The first record, or nil if the iterator has none.
+//   - error: An error if the iterator fails or the value could not be decoded into T.
+func First[T any](iterator StateQueryIteratorInterface) (*T, error) {
+	var first *T
+	err := ForEach(iterator, func(key string, record T) error {
+		first = &record
+		return ErrStopIteration
+	})
+	if err != nil {
+		return nil, err
+	}
+	return first, nil
+}
+
+// Count returns the number of key/value pairs of the iterator, stopping at `limit` if it is positive. The values
+// are not decoded. The iterator is closed when Count returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a range, partial composite key or rich query.
+//   - limit: The count at which to stop reading the iterator, or 0 to read it all.
+//
+// Returns:
+//   - int: The number of key/value pairs read.
+//   - error: An error if the iterator fails.
+func Count(iterator StateQueryIteratorInterface, limit int) (int, error) {
+	defer iterator.Close()
+
+	count := 0
+	for (limit <= 0 || count < limit) && iterator.HasNext() {
+		if _, err := iterator.Next(); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// ForEachHistory decodes every modification of a history query into a HistoryEntry and calls fn with it, until fn
+// returns an error or the iterator is exhausted. The iterator is closed when ForEachHistory returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by GetHistoryForKey.
+//   - fn: Called for every modification. Returning ErrStopIteration stops the iteration without an error.
+//
+// Returns:
+//   - error: An error if the iterator fails, a value could not be decoded into T, or fn returns an error other than
+//     ErrStopIteration.
+func ForEachHistory[T any](iterator HistoryQueryIteratorInterface, fn func(entry HistoryEntry[T]) error) error {
+	defer iterator.Close()
+
+	for iterator.HasNext() {
+		modification, err := iterator.Next()
+		if err != nil {
+			return err
+		}
+		entry := HistoryEntry[T]{TxID: modification.TxId, IsDelete: modification.IsDelete}
+		if modification.Timestamp != nil {
+			entry.Timestamp = modification.Timestamp.AsTime()
+		}
+		if !modification.IsDelete {
+			entry.Record = new(T)
+			if err := json.Unmarshal(modification.Value, entry.Record); err != nil {
+				return fmt.Errorf("failed to unmarshal value of transaction %s: %v", modification.TxId, err)
+			}
+		}
+		if err := fn(entry); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// CollectHistory decodes every modification of a history query into a HistoryEntry. The iterator is closed when
+// CollectHistory returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by GetHistoryForKey.
+//
+// Returns:
+//   - []HistoryEntry[T]: The modifications, in the order returned by the peer.
+//   - error: An error if the iterator fails or a value could not be decoded into T.
+func CollectHistory[T any](iterator HistoryQueryIteratorInterface) ([]HistoryEntry[T], error) {
+	entries := []HistoryEntry[T]{}
+	err := ForEachHistory(iterator, func(entry HistoryEntry[T]) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
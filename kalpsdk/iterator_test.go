@@ -0,0 +1,180 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+	"time"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newMockIterator returns an iterator over the given key/value pairs that must be closed.
+func newMockIterator(kvs ...*queryresult.KV) *mocks.StateQueryIteratorInterface {
+	iterator := new(mocks.StateQueryIteratorInterface)
+	for _, kv := range kvs {
+		iterator.On("HasNext").Return(true).Once()
+		iterator.On("Next").Return(kv, nil).Once()
+	}
+	iterator.On("HasNext").Return(false)
+	iterator.On("Close").Return(nil).Once()
+	return iterator
+}
+
+func testAssetKVs() []*queryresult.KV {
+	return []*queryresult.KV{
+		{Key: "a1", Value: []byte(`{"id":"a1","owner":"alice"}`)},
+		{Key: "a2", Value: []byte(`{"id":"a2","owner":"bob"}`)},
+		{Key: "a3", Value: []byte(`{"id":"a3","owner":"alice"}`)},
+	}
+}
+
+func TestIteratorHelpers(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		iterator := newMockIterator(testAssetKVs()...)
+		assets, err := Collect[testAsset](iterator)
+		require.NoError(t, err)
+		require.Equal(t, []testAsset{{ID: "a1", Owner: "alice"}, {ID: "a2", Owner: "bob"}, {ID: "a3", Owner: "alice"}}, assets)
+		iterator.AssertExpectations(t)
+
+		iterator = newMockIterator(testAssetKVs()...)
+		owners, err := Map(iterator, func(key string, asset testAsset) (string, error) {
+			return key + ":" + asset.Owner, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a1:alice", "a2:bob", "a3:alice"}, owners)
+		iterator.AssertExpectations(t)
+
+		iterator = newMockIterator(testAssetKVs()...)
+		assets, err = Filter(iterator, func(key string, asset testAsset) bool { return asset.Owner == "alice" })
+		require.NoError(t, err)
+		require.Equal(t, []testAsset{{ID: "a1", Owner: "alice"}, {ID: "a3", Owner: "alice"}}, assets)
+		iterator.AssertExpectations(t)
+
+		iterator = newMockIterator()
+		assets, err = Collect[testAsset](iterator)
+		require.NoError(t, err)
+		require.Empty(t, assets)
+		require.NotNil(t, assets)
+		iterator.AssertExpectations(t)
+	})
+
+	// Check that the iteration stops early and still closes the iterator
+	t.Run("Check for early stop", func(t *testing.T) {
+		iterator := newMockIterator(testAssetKVs()...)
+		first, err := First[testAsset](iterator)
+		require.NoError(t, err)
+		require.Equal(t, &testAsset{ID: "a1", Owner: "alice"}, first)
+		iterator.AssertNumberOfCalls(t, "Next", 1)
+		iterator.AssertCalled(t, "Close")
+
+		iterator = newMockIterator()
+		first, err = First[testAsset](iterator)
+		require.NoError(t, err)
+		require.Nil(t, first)
+		iterator.AssertExpectations(t)
+
+		var keys []string
+		iterator = newMockIterator(testAssetKVs()...)
+		err = ForEach(iterator, func(key string, asset testAsset) error {
+			keys = append(keys, key)
+			if asset.Owner == "bob" {
+				return ErrStopIteration
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a1", "a2"}, keys)
+		iterator.AssertCalled(t, "Close")
+
+		iterator = newMockIterator(testAssetKVs()...)
+		count, err := Count(iterator, 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+		iterator.AssertCalled(t, "Close")
+
+		iterator = newMockIterator(testAssetKVs()...)
+		count, err = Count(iterator, 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+		iterator.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		iterator := newMockIterator(&queryresult.KV{Key: "a1", Value: []byte(`not json`)})
+		_, err := Collect[testAsset](iterator)
+		require.ErrorContains(t, err, "failed to unmarshal value of key a1")
+		iterator.AssertExpectations(t)
+
+		iterator = newMockIterator(testAssetKVs()...)
+		_, err = Map(iterator, func(key string, asset testAsset) (string, error) {
+			return "", fmt.Errorf("asset %s is frozen", key)
+		})
+		require.EqualError(t, err, "asset a1 is frozen")
+		iterator.AssertCalled(t, "Close")
+
+		iterator = new(mocks.StateQueryIteratorInterface)
+		iterator.On("HasNext").Return(true).Once()
+		iterator.On("Next").Return(nil, fmt.Errorf("iterator failed")).Once()
+		iterator.On("Close").Return(nil).Once()
+		_, err = Count(iterator, 0)
+		require.EqualError(t, err, "iterator failed")
+		iterator.AssertExpectations(t)
+	})
+}
+
+func TestHistoryHelpers(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newHistoryIterator := func(modifications ...*queryresult.KeyModification) *mocks.HistoryQueryIteratorInterface {
+		iterator := new(mocks.HistoryQueryIteratorInterface)
+		for _, modification := range modifications {
+			iterator.On("HasNext").Return(true).Once()
+			iterator.On("Next").Return(modification, nil).Once()
+		}
+		iterator.On("HasNext").Return(false)
+		iterator.On("Close").Return(nil).Once()
+		return iterator
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		iterator := newHistoryIterator(
+			&queryresult.KeyModification{TxId: "tx2", IsDelete: true, Timestamp: timestamppb.New(timestamp)},
+			&queryresult.KeyModification{TxId: "tx1", Value: []byte(`{"id":"a1","owner":"alice"}`), Timestamp: timestamppb.New(timestamp)},
+		)
+		entries, err := CollectHistory[testAsset](iterator)
+		require.NoError(t, err)
+		require.Equal(t, []HistoryEntry[testAsset]{
+			{TxID: "tx2", Timestamp: timestamp, IsDelete: true},
+			{TxID: "tx1", Timestamp: timestamp, Record: &testAsset{ID: "a1", Owner: "alice"}},
+		}, entries)
+		iterator.AssertExpectations(t)
+
+		var txIDs []string
+		iterator = newHistoryIterator(
+			&queryresult.KeyModification{TxId: "tx2", IsDelete: true},
+			&queryresult.KeyModification{TxId: "tx1", Value: []byte(`{}`)},
+		)
+		err = ForEachHistory(iterator, func(entry HistoryEntry[testAsset]) error {
+			txIDs = append(txIDs, entry.TxID)
+			return ErrStopIteration
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"tx2"}, txIDs)
+		iterator.AssertCalled(t, "Close")
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		iterator := newHistoryIterator(&queryresult.KeyModification{TxId: "tx1", Value: []byte(`not json`)})
+		_, err := CollectHistory[testAsset](iterator)
+		require.ErrorContains(t, err, "failed to unmarshal value of transaction tx1")
+		iterator.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,182 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// defaultChannel is the channel the stub of a Harness is bound to.
+const defaultChannel = "kalptest"
+
+// Harness drives a chaincode built with kalpsdk.NewChaincode through ContractChaincode.Invoke, the same entry point
+// the peer uses, so that function routing and the before, after and unknown transaction hooks of the contracts run
+// exactly as they would on the network. The ledger is kept in a MemStub shared by all calls, and the writes of every
+// successful Submit are committed so that multi-step scenarios build on each other.
+type Harness struct {
+	Chaincode *kalpsdk.ContractChaincode // The chaincode under test.
+	Stub      *MemStub                   // The in-memory ledger shared by all transactions.
+
+	creator   []byte
+	transient map[string][]byte
+	txCount   int
+}
+
+// Result is the outcome of a transaction run through the Harness.
+type Result struct {
+	TxID     string               // The ID assigned to the transaction.
+	Response peer.Response        // The response returned by ContractChaincode.Invoke.
+	Event    *peer.ChaincodeEvent // The event set by the transaction, nil if none.
+	WriteSet []KVWrite            // The committed write set; always empty for evaluated or failed transactions.
+}
+
+// NewHarness creates a chaincode from the given contracts with kalpsdk.NewChaincode and binds it to an empty
+// in-memory ledger.
+//
+// Parameters:
+//   - contracts: The contracts implementing the chaincode functionality.
+//
+// Returns:
+//   - *Harness: The initialized harness.
+//   - error: An error if the chaincode could not be created.
+func NewHarness(contracts ...contractapi.ContractInterface) (*Harness, error) {
+	chaincode, err := kalpsdk.NewChaincode(contracts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Harness{Chaincode: chaincode, Stub: NewMemStub(defaultChannel)}, nil
+}
+
+// SetCreator sets the serialized identity (a marshaled msp.SerializedIdentity) that submits the following
+// transactions.
+func (h *Harness) SetCreator(creator []byte) {
+	h.creator = creator
+}
+
+// SetTransient sets the transient map passed to the following transactions. A nil map clears it.
+func (h *Harness) SetTransient(transient map[string][]byte) {
+	h.transient = transient
+}
+
+// SetIdentity makes the given identity submit the following transactions of the harness.
+//
+// Parameters:
+//   - id: The identity submitting the transactions.
+//
+// Returns:
+//   - error: An error if the identity could not be serialized.
+func (h *Harness) SetIdentity(id *Identity) error {
+	creator, err := id.Serialize()
+	if err != nil {
+		return err
+	}
+	h.SetCreator(creator)
+	return nil
+}
+
+// Init calls ContractChaincode.Init with the given function and arguments, as the peer does when the chaincode is
+// initialized. If the chaincode responds with shim.OK the write set is committed to the ledger.
+//
+// Parameters:
+//   - function: The init function, e.g. kalpsdk.KYCConfigInitFunction, or empty for a plain init.
+//   - args: The string arguments passed to the function.
+//
+// Returns:
+//   - *Result: The response and committed write set of the init transaction.
+func (h *Harness) Init(function string, args ...string) *Result {
+	txID := h.startTransaction(function, args)
+	response := h.Chaincode.Init(h.Stub)
+	result := &Result{TxID: txID, Response: response, Event: h.Stub.Event(), WriteSet: []KVWrite{}}
+	if result.Response.Status == shim.OK {
+		result.WriteSet = h.Stub.CommitTransaction()
+	} else {
+		h.Stub.AbortTransaction()
+	}
+	return result
+}
+
+// Submit invokes the named function as a submitted transaction. If the chaincode responds with shim.OK the write
+// set of the transaction is committed to the ledger, otherwise it is discarded.
+//
+// Parameters:
+//   - function: The function to invoke, optionally prefixed with the contract name and a colon.
+//   - args: The string arguments passed to the function.
+//
+// Returns:
+//   - *Result: The response, event and committed write set of the transaction.
+func (h *Harness) Submit(function string, args ...string) *Result {
+	result := h.invoke(function, args)
+	if result.Response.Status == shim.OK {
+		result.WriteSet = h.Stub.CommitTransaction()
+	} else {
+		h.Stub.AbortTransaction()
+	}
+	return result
+}
+
+// Evaluate invokes the named function as a query. The write set of the transaction is always discarded.
+//
+// Parameters:
+//   - function: The function to invoke, optionally prefixed with the contract name and a colon.
+//   - args: The string arguments passed to the function.
+//
+// Returns:
+//   - *Result: The response and event of the transaction.
+func (h *Harness) Evaluate(function string, args ...string) *Result {
+	result := h.invoke(function, args)
+	h.Stub.AbortTransaction()
+	return result
+}
+
+// invoke starts a new transaction on the stub and routes it through the chaincode.
+func (h *Harness) invoke(function string, args []string) *Result {
+	txID := h.startTransaction(function, args)
+	response := h.Chaincode.Invoke(h.Stub)
+	return &Result{TxID: txID, Response: response, Event: h.Stub.Event(), WriteSet: []KVWrite{}}
+}
+
+// startTransaction starts a new transaction on the stub submitted by the current creator, with the current
+// transient map.
+func (h *Harness) startTransaction(function string, args []string) string {
+	h.txCount++
+	txID := fmt.Sprintf("tx%d", h.txCount)
+
+	h.Stub.StartTransaction(txID)
+	h.Stub.SetCreator(h.creator)
+	h.Stub.SetTransient(h.transient)
+	h.Stub.SetFunctionAndParameters(function, args...)
+	return txID
+}
+
+// Err returns the error message of a failed transaction as an error, or nil if the transaction succeeded.
+func (r *Result) Err() error {
+	if r.Response.Status == shim.OK {
+		return nil
+	}
+	return fmt.Errorf("transaction %s failed with status %d: %s", r.TxID, r.Response.Status, r.Response.Message)
+}
+
+// Unmarshal decodes the JSON payload of a successful transaction into v.
+//
+// Parameters:
+//   - v: A pointer to the value receiving the decoded payload.
+//
+// Returns:
+//   - error: The transaction error if it failed, or an error if the payload could not be decoded.
+func (r *Result) Unmarshal(v interface{}) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(r.Response.Payload, v); err != nil {
+		return fmt.Errorf("failed to unmarshal payload of transaction %s: %v", r.TxID, err)
+	}
+	return nil
+}
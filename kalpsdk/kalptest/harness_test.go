@@ -0,0 +1,158 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/stretchr/testify/require"
+)
+
+// counterContract is a minimal contract used to exercise the Harness.
+type counterContract struct {
+	kalpsdk.Contract
+}
+
+type counter struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func (c *counterContract) Increment(ctx kalpsdk.TransactionContextInterface, name string) (*counter, error) {
+	current, err := c.Read(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	current.Value++
+
+	value := []byte(fmt.Sprintf(`{"name":%q,"value":%d}`, name, current.Value))
+	if err := ctx.PutStateWithoutKYC(name, value); err != nil {
+		return nil, err
+	}
+	if err := ctx.SetEvent("Incremented", value); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+func (c *counterContract) Read(ctx kalpsdk.TransactionContextInterface, name string) (*counter, error) {
+	value, err := ctx.GetState(name)
+	if err != nil {
+		return nil, err
+	}
+	current := &counter{Name: name}
+	if value != nil {
+		_, err = fmt.Sscanf(string(value), `{"name":%q,"value":%d}`, &current.Name, &current.Value)
+	}
+	return current, err
+}
+
+func (c *counterContract) Fail(ctx kalpsdk.TransactionContextInterface, name string) error {
+	if err := ctx.PutStateWithoutKYC(name, []byte("garbage")); err != nil {
+		return err
+	}
+	return fmt.Errorf("failed on purpose")
+}
+
+func TestHarnessSubmit(t *testing.T) {
+	harness, err := NewHarness(&counterContract{})
+	require.NoError(t, err)
+
+	// Check that submitted writes are committed between calls
+	t.Run("Check for success response", func(t *testing.T) {
+		result := harness.Submit("Increment", "clicks")
+		require.NoError(t, result.Err())
+		require.Equal(t, []KVWrite{{Key: "clicks", Value: []byte(`{"name":"clicks","value":1}`)}}, result.WriteSet)
+		require.Equal(t, "Incremented", result.Event.EventName)
+
+		result = harness.Submit("Increment", "clicks")
+		var c counter
+		require.NoError(t, result.Unmarshal(&c))
+		require.Equal(t, counter{Name: "clicks", Value: 2}, c)
+	})
+
+	// Check that failed transactions do not commit their writes
+	t.Run("Check for failure response", func(t *testing.T) {
+		result := harness.Submit("Fail", "clicks")
+		require.Equal(t, int32(shim.ERROR), result.Response.Status)
+		require.EqualError(t, result.Err(), "transaction "+result.TxID+" failed with status 500: failed on purpose")
+		require.Empty(t, result.WriteSet)
+
+		value, err := harness.Stub.GetState("clicks")
+		require.NoError(t, err)
+		require.Equal(t, `{"name":"clicks","value":2}`, string(value))
+	})
+
+	// Check that unknown functions are rejected by the router
+	t.Run("Check for unknown function", func(t *testing.T) {
+		result := harness.Submit("Decrement", "clicks")
+		require.EqualError(t, result.Err(), "transaction "+result.TxID+" failed with status 500: Function Decrement not found in contract counterContract")
+	})
+}
+
+func TestHarnessEvaluate(t *testing.T) {
+	harness, err := NewHarness(&counterContract{})
+	require.NoError(t, err)
+
+	result := harness.Evaluate("Increment", "clicks")
+	require.NoError(t, result.Err())
+	require.Empty(t, result.WriteSet)
+
+	var c counter
+	require.NoError(t, harness.Evaluate("Read", "clicks").Unmarshal(&c))
+	require.Equal(t, 0, c.Value)
+}
+
+// secretContract stores a value passed in the transient map encrypted on the public ledger.
+type secretContract struct {
+	kalpsdk.Contract
+}
+
+func (c *secretContract) Store(ctx kalpsdk.TransactionContextInterface, key string) error {
+	secret, err := kalpsdk.ReadTransient[string](ctx, "secret")
+	if err != nil {
+		return err
+	}
+	ciphertext, err := kalpsdk.EncryptWithTransientKey(ctx, "key", []byte(secret))
+	if err != nil {
+		return err
+	}
+	return ctx.PutStateWithoutKYC(key, ciphertext)
+}
+
+func (c *secretContract) Reveal(ctx kalpsdk.TransactionContextInterface, key string) (string, error) {
+	ciphertext, err := ctx.GetState(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := kalpsdk.DecryptWithTransientKey(ctx, "key", ciphertext)
+	return string(plaintext), err
+}
+
+func TestHarnessSetTransient(t *testing.T) {
+	harness, err := NewHarness(&secretContract{})
+	require.NoError(t, err)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	// Check for success response
+	harness.SetTransient(map[string][]byte{"secret": []byte(`"kyc-hash"`), "key": key})
+	result := harness.Submit("Store", "record1")
+	require.NoError(t, result.Err())
+	require.Len(t, result.WriteSet, 1)
+	require.NotContains(t, string(result.WriteSet[0].Value), "kyc-hash")
+	require.NotContains(t, string(harness.Stub.GetArgs()[1]), "kyc-hash")
+
+	harness.SetTransient(map[string][]byte{"key": key})
+	result = harness.Evaluate("Reveal", "record1")
+	require.NoError(t, result.Err())
+	require.Equal(t, "kyc-hash", string(result.Response.Payload))
+
+	// Check for failure response
+	harness.SetTransient(nil)
+	require.ErrorContains(t, harness.Evaluate("Reveal", "record1").Err(), "transient key key not found")
+}
@@ -0,0 +1,141 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	//Third party Libs
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/attrmgr"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// IdentitySpec describes the subject and attributes of a test identity.
+type IdentitySpec struct {
+	CommonName          string            // The CN of the certificate subject, returned by GetUserID.
+	OrganizationalUnits []string          // The OU values of the certificate subject, e.g. "client".
+	Organization        string            // The O value of the certificate subject.
+	MSPID               string            // The MSP the identity belongs to.
+	Attributes          map[string]string // Fabric CA attributes embedded in the hf.Attrs certificate extension.
+}
+
+// Identity is a client identity backed by a freshly generated, self-signed X.509 certificate, shaped like the
+// enrollment certificates issued by a Fabric CA.
+type Identity struct {
+	MSPID       string            // The MSP the identity belongs to.
+	Certificate *x509.Certificate // The parsed certificate.
+	CertPEM     []byte            // The PEM encoded certificate.
+	PrivateKey  *ecdsa.PrivateKey // The key the certificate was issued for.
+}
+
+// NewIdentity generates a self-signed ECDSA P-256 certificate for the given spec.
+//
+// Parameters:
+//   - spec: The subject, MSP ID and attributes of the identity.
+//
+// Returns:
+//   - *Identity: The generated identity.
+//   - error: An error if the spec is incomplete or the certificate could not be generated.
+func NewIdentity(spec IdentitySpec) (*Identity, error) {
+	if spec.CommonName == "" {
+		return nil, fmt.Errorf("identity common name must not be empty")
+	}
+	if spec.MSPID == "" {
+		return nil, fmt.Errorf("identity MSP ID must not be empty")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key for identity %s: %v", spec.CommonName, err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number for identity %s: %v", spec.CommonName, err)
+	}
+
+	subject := pkix.Name{CommonName: spec.CommonName, OrganizationalUnit: spec.OrganizationalUnits}
+	if spec.Organization != "" {
+		subject.Organization = []string{spec.Organization}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	if len(spec.Attributes) > 0 {
+		attrs, err := json.Marshal(&attrmgr.Attributes{Attrs: spec.Attributes})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal attributes of identity %s: %v", spec.CommonName, err)
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: attrmgr.AttrOID, Value: attrs})
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate for identity %s: %v", spec.CommonName, err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate of identity %s: %v", spec.CommonName, err)
+	}
+
+	return &Identity{
+		MSPID:       spec.MSPID,
+		Certificate: cert,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		PrivateKey:  key,
+	}, nil
+}
+
+// Serialize returns the identity as a marshaled msp.SerializedIdentity, the format returned by GetCreator.
+//
+// Returns:
+//   - []byte: The serialized identity.
+//   - error: An error if the identity could not be marshaled.
+func (id *Identity) Serialize() ([]byte, error) {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: id.MSPID, IdBytes: id.CertPEM})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize identity: %v", err)
+	}
+	return creator, nil
+}
+
+// ClientIdentity returns the cid.ClientIdentity a chaincode sees when the identity submits a transaction.
+//
+// Returns:
+//   - cid.ClientIdentity: The client identity built from the serialized identity.
+//   - error: An error if the identity could not be serialized or parsed.
+func (id *Identity) ClientIdentity() (cid.ClientIdentity, error) {
+	creator, err := id.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	ci, err := cid.New(creatorStub(creator))
+	if err != nil {
+		return nil, err
+	}
+	return ci, nil
+}
+
+// creatorStub is the minimal stub cid.New needs to build a client identity.
+type creatorStub []byte
+
+// GetCreator returns the serialized identity.
+func (c creatorStub) GetCreator() ([]byte, error) {
+	return c, nil
+}
@@ -0,0 +1,195 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"encoding/base64"
+	"testing"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdentity(t *testing.T) {
+	id, err := NewIdentity(IdentitySpec{
+		CommonName:          "alice",
+		OrganizationalUnits: []string{"client", "department1"},
+		Organization:        "Org1",
+		MSPID:               "Org1MSP",
+		Attributes:          map[string]string{"role": "admin"},
+	})
+	require.NoError(t, err)
+
+	ci, err := id.ClientIdentity()
+	require.NoError(t, err)
+
+	// Check the ID has the shape produced for Fabric CA certificates
+	b64ID, err := ci.GetID()
+	require.NoError(t, err)
+	decodedID, err := base64.StdEncoding.DecodeString(b64ID)
+	require.NoError(t, err)
+	require.Equal(t, "x509::CN=alice,OU=client+OU=department1,O=Org1::CN=alice,OU=client+OU=department1,O=Org1", string(decodedID))
+
+	mspID, err := ci.GetMSPID()
+	require.NoError(t, err)
+	require.Equal(t, "Org1MSP", mspID)
+
+	// Check the hf.Attrs extension is readable
+	require.NoError(t, ci.AssertAttributeValue("role", "admin"))
+	_, found, err := ci.GetAttributeValue("email")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	hasOU, err := ci.(*cid.ClientID).HasOUValue("department1")
+	require.NoError(t, err)
+	require.True(t, hasOU)
+
+	// Check for failure response
+	_, err = NewIdentity(IdentitySpec{MSPID: "Org1MSP"})
+	require.EqualError(t, err, "identity common name must not be empty")
+	_, err = NewIdentity(IdentitySpec{CommonName: "alice"})
+	require.EqualError(t, err, "identity MSP ID must not be empty")
+}
+
+func TestValidateCreateTokenTransactionWithIdentity(t *testing.T) {
+	id, err := NewIdentity(IdentitySpec{CommonName: "alice", OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+	ci, err := id.ClientIdentity()
+	require.NoError(t, err)
+
+	stub := newQueryStub()
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetStub(stub)
+	ctx.SetClientIdentity(ci)
+
+	userID, err := ctx.GetUserID()
+	require.NoError(t, err)
+	require.Equal(t, "alice", userID)
+
+	// Check for success response
+	require.NoError(t, ctx.ValidateCreateTokenTransaction("asset4", "NIU", []string{"alice"}))
+
+	// Check for failure response
+	require.EqualError(t, ctx.ValidateCreateTokenTransaction("asset4", "NIU", []string{"bob"}), "only the asset owner is allowed to initiate create transaction")
+	require.EqualError(t, ctx.ValidateCreateTokenTransaction("asset1", "NIU", []string{"alice"}), "the token with ID 'asset1' is already minted")
+}
+
+func TestHarnessSetIdentity(t *testing.T) {
+	harness, err := NewHarness(&whoAmIContract{})
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		id, err := NewIdentity(IdentitySpec{CommonName: name, OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+		require.NoError(t, err)
+		require.NoError(t, harness.SetIdentity(id))
+
+		result := harness.Evaluate("WhoAmI")
+		require.NoError(t, result.Err())
+		require.Equal(t, name, string(result.Response.Payload))
+	}
+}
+
+// whoAmIContract returns the user ID of the submitting identity.
+type whoAmIContract struct {
+	kalpsdk.Contract
+}
+
+func (c *whoAmIContract) WhoAmI(ctx kalpsdk.TransactionContextInterface) (string, error) {
+	return ctx.GetUserID()
+}
+
+func TestGetUserIdentityWithIdentity(t *testing.T) {
+	id, err := NewIdentity(IdentitySpec{
+		CommonName:          "Smith, John",
+		OrganizationalUnits: []string{"client", "department1"},
+		Organization:        "Org1",
+		MSPID:               "Org1MSP",
+	})
+	require.NoError(t, err)
+	ci, err := id.ClientIdentity()
+	require.NoError(t, err)
+
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetClientIdentity(ci)
+
+	// Check that escaped characters in the subject are handled
+	userID, err := ctx.GetUserID()
+	require.NoError(t, err)
+	require.Equal(t, "Smith, John", userID)
+
+	identity, err := ctx.GetUserIdentity()
+	require.NoError(t, err)
+	require.Equal(t, "Smith, John", identity.CommonName)
+	require.Equal(t, []string{"client", "department1"}, identity.OrganizationalUnits)
+	require.Equal(t, "Org1", identity.Organization)
+	require.Equal(t, "Org1MSP", identity.MSPID)
+	// The test certificates are self-signed
+	require.Equal(t, identity.Subject, identity.Issuer)
+}
+
+func TestRequireAttributeWithIdentity(t *testing.T) {
+	harness, err := NewHarness(&adminContract{})
+	require.NoError(t, err)
+
+	admin, err := NewIdentity(IdentitySpec{CommonName: "alice", MSPID: "Org1MSP", Attributes: map[string]string{"role": "admin"}})
+	require.NoError(t, err)
+	auditor, err := NewIdentity(IdentitySpec{CommonName: "bob", MSPID: "Org2MSP", Attributes: map[string]string{"role": "auditor"}})
+	require.NoError(t, err)
+
+	// Check for success response
+	require.NoError(t, harness.SetIdentity(admin))
+	require.NoError(t, harness.Evaluate("AdminOnly").Err())
+
+	// Check for failure response
+	require.NoError(t, harness.SetIdentity(auditor))
+	result := harness.Evaluate("AdminOnly")
+	require.Equal(t, "access denied: none of the conditions is satisfied: attribute role must be admin; MSP Org2MSP is not one of [Org1MSP]", result.Response.Message)
+}
+
+// adminContract only lets admins of Org1MSP through.
+type adminContract struct {
+	kalpsdk.Contract
+}
+
+func (c *adminContract) AdminOnly(ctx kalpsdk.TransactionContextInterface) error {
+	return ctx.RequireAnyOf(kalpsdk.HasAttribute("role", "admin"), kalpsdk.HasMSP("Org1MSP"))
+}
+
+func TestAccessPoliciesThroughHarness(t *testing.T) {
+	contract := &adminContract{}
+	contract.Name = "admin"
+	contract.AccessPolicies = map[string]kalpsdk.AccessPolicy{
+		"Restricted": {Roles: []string{"admin"}, KYCLevel: kalpsdk.KYCLevelBasic},
+	}
+	harness, err := NewHarness(contract)
+	require.NoError(t, err)
+	kyc := RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+
+	alice, err := NewIdentity(IdentitySpec{CommonName: "alice", MSPID: "Org1MSP", Attributes: map[string]string{"role": "admin"}})
+	require.NoError(t, err)
+	bob, err := NewIdentity(IdentitySpec{CommonName: "bob", MSPID: "Org1MSP", Attributes: map[string]string{"role": "admin"}})
+	require.NoError(t, err)
+
+	// Check for success response
+	require.NoError(t, harness.SetIdentity(alice))
+	require.NoError(t, harness.Submit("admin:Restricted").Err())
+
+	// Check that the KYC requirement of the policy is enforced
+	require.NoError(t, harness.SetIdentity(bob))
+	require.Equal(t, "access denied: user bob has not completed KYC", harness.Submit("admin:Restricted").Response.Message)
+
+	// Check that functions without a policy are not restricted
+	require.NoError(t, harness.Submit("admin:Open").Err())
+}
+
+func (c *adminContract) Restricted(ctx kalpsdk.TransactionContextInterface) error {
+	return nil
+}
+
+func (c *adminContract) Open(ctx kalpsdk.TransactionContextInterface) error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"fmt"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+)
+
+// stateIterator iterates over a precomputed result set of a state query.
+// It implements shim.StateQueryIteratorInterface.
+type stateIterator struct {
+	results []*queryresult.KV
+	pos     int
+	closed  bool
+}
+
+// newStateIterator creates a stateIterator over the given results.
+func newStateIterator(results []*queryresult.KV) *stateIterator {
+	return &stateIterator{results: results}
+}
+
+// HasNext returns true if the range query iterator contains additional keys and values.
+func (it *stateIterator) HasNext() bool {
+	return !it.closed && it.pos < len(it.results)
+}
+
+// Next returns the next key and value in the range and execute query iterator.
+func (it *stateIterator) Next() (*queryresult.KV, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("no more results in the state query iterator")
+	}
+	kv := it.results[it.pos]
+	it.pos++
+	return kv, nil
+}
+
+// Close closes the iterator. HasNext returns false once the iterator is closed.
+func (it *stateIterator) Close() error {
+	it.closed = true
+	return nil
+}
+
+// historyIterator iterates over the modifications of a key.
+// It implements shim.HistoryQueryIteratorInterface.
+type historyIterator struct {
+	results []*queryresult.KeyModification
+	pos     int
+	closed  bool
+}
+
+// HasNext returns true if the history query iterator contains additional modifications.
+func (it *historyIterator) HasNext() bool {
+	return !it.closed && it.pos < len(it.results)
+}
+
+// Next returns the next key modification in the history query iterator.
+func (it *historyIterator) Next() (*queryresult.KeyModification, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("no more results in the history query iterator")
+	}
+	km := it.results[it.pos]
+	it.pos++
+	return km, nil
+}
+
+// Close closes the iterator. HasNext returns false once the iterator is closed.
+func (it *historyIterator) Close() error {
+	it.closed = true
+	return nil
+}
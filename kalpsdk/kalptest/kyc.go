@@ -0,0 +1,170 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// KYCRecord is the record stored by the KYCChaincode for each user.
+type KYCRecord struct {
+	ID      string `json:"id"`      // The ID of the user.
+	KycID   string `json:"kycId"`   // The ID of the KYC record.
+	KycHash string `json:"kycHash"` // The hash value representing the KYC information.
+}
+
+// KYCChaincode is a local stand-in for the kyc chaincode queried by GetKYC and written by PutKYC. It answers
+// KycExists with a "true" or "false" payload and stores a KYCRecord for each CreateKyc call, keyed by user ID.
+// Responses can be overridden per function to simulate failures of the real chaincode.
+type KYCChaincode struct {
+	stub           *MemStub
+	existsFunction string
+	createFunction string
+	responses      map[string]pb.Response
+	calls          map[string]int
+}
+
+// RegisterKYCChaincode registers a new KYCChaincode as "kyc" on the channel of the given stub, so that GetKYC,
+// PutKYC and the KYC variants of the state functions reach it through InvokeChaincode.
+//
+// Parameters:
+//   - stub: The stub of the chaincode under test.
+//
+// Returns:
+//   - *KYCChaincode: The registered KYC chaincode.
+func RegisterKYCChaincode(stub *MemStub) *KYCChaincode {
+	return RegisterKYCChaincodeWithConfig(stub, kalpsdk.DefaultKYCConfig())
+}
+
+// RegisterKYCChaincodeWithConfig registers a new KYCChaincode under the chaincode name, channel and function
+// names of the given configuration. Empty fields take their default values.
+//
+// Parameters:
+//   - stub: The stub of the chaincode under test.
+//   - config: The KYC configuration the chaincode under test uses.
+//
+// Returns:
+//   - *KYCChaincode: The registered KYC chaincode.
+func RegisterKYCChaincodeWithConfig(stub *MemStub, config kalpsdk.KYCConfig) *KYCChaincode {
+	config = config.WithDefaults()
+	kyc := &KYCChaincode{
+		existsFunction: config.ExistsFunction,
+		createFunction: config.CreateFunction,
+		responses:      make(map[string]pb.Response),
+		calls:          make(map[string]int),
+	}
+	kyc.stub = stub.RegisterChaincode(config.ChaincodeName, config.ChannelName, kyc)
+	return kyc
+}
+
+// AddKYC stores a committed KYC record for the user, as if CreateKyc had been submitted earlier.
+//
+// Parameters:
+//   - id: The ID of the user.
+//   - kycId: The ID of the KYC record.
+//   - kycHash: The hash value representing the KYC information.
+//
+// Returns:
+//   - error: An error if the record could not be marshaled.
+func (k *KYCChaincode) AddKYC(id string, kycId string, kycHash string) error {
+	record, err := json.Marshal(KYCRecord{ID: id, KycID: kycId, KycHash: kycHash})
+	if err != nil {
+		return fmt.Errorf("failed to marshal kyc record of user %s: %v", id, err)
+	}
+	k.stub.SetCommittedState(id, record)
+	return nil
+}
+
+// GetRecord returns the committed KYC record of the user, or nil if the user has not completed KYC.
+//
+// Parameters:
+//   - id: The ID of the user.
+//
+// Returns:
+//   - *KYCRecord: The KYC record of the user.
+//   - error: An error if the stored record could not be unmarshaled.
+func (k *KYCChaincode) GetRecord(id string) (*KYCRecord, error) {
+	value, _ := k.stub.GetState(id)
+	if value == nil {
+		return nil, nil
+	}
+	var record KYCRecord
+	if err := json.Unmarshal(value, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal kyc record of user %s: %v", id, err)
+	}
+	return &record, nil
+}
+
+// InjectResponse makes every following call of `function` return `response` instead of being handled, e.g.
+// shim.Error("kyc unavailable") for a failing chaincode or shim.Success([]byte("maybe")) for a malformed payload.
+//
+// Parameters:
+//   - function: The function whose response is overridden, e.g. "KycExists" or "CreateKyc".
+//   - response: The response returned for the function.
+func (k *KYCChaincode) InjectResponse(function string, response pb.Response) {
+	k.responses[function] = response
+}
+
+// Calls returns how often `function` has been invoked, including invocations answered by an injected response.
+func (k *KYCChaincode) Calls(function string) int {
+	return k.calls[function]
+}
+
+// ResetResponses removes all injected responses.
+func (k *KYCChaincode) ResetResponses() {
+	k.responses = make(map[string]pb.Response)
+}
+
+// Init is called when the chaincode is instantiated and does nothing.
+func (k *KYCChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke handles the exists and create functions, KycExists and CreateKyc by default.
+func (k *KYCChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	fn, params := stub.GetFunctionAndParameters()
+	k.calls[fn]++
+	if response, ok := k.responses[fn]; ok {
+		return response
+	}
+
+	switch fn {
+	case k.existsFunction:
+		if len(params) != 1 {
+			return shim.Error(fmt.Sprintf("incorrect number of arguments for %s. Expecting 1, got %d", fn, len(params)))
+		}
+		value, err := stub.GetState(params[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(strconv.FormatBool(value != nil)))
+	case k.createFunction:
+		if len(params) != 3 {
+			return shim.Error(fmt.Sprintf("incorrect number of arguments for %s. Expecting 3, got %d", fn, len(params)))
+		}
+		value, err := stub.GetState(params[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if value != nil {
+			return shim.Error(fmt.Sprintf("kyc already exists for user %s", params[0]))
+		}
+		record, err := json.Marshal(KYCRecord{ID: params[0], KycID: params[1], KycHash: params[2]})
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutState(params[0], record); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	}
+	return shim.Error(fmt.Sprintf("function %s not found in kyc chaincode", fn))
+}
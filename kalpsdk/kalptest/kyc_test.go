@@ -0,0 +1,249 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"testing"
+	"time"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/stretchr/testify/require"
+)
+
+// kycContract writes and deletes keys with the KYC variants of the state functions.
+type kycContract struct {
+	kalpsdk.Contract
+}
+
+func (c *kycContract) Put(ctx kalpsdk.TransactionContextInterface, key string, value string) error {
+	return ctx.PutStateWithKYC(key, []byte(value))
+}
+
+func (c *kycContract) PutMany(ctx kalpsdk.TransactionContextInterface, keys []string) error {
+	for _, key := range keys {
+		if err := ctx.PutStateWithKYC(key, []byte(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *kycContract) PutEnhanced(ctx kalpsdk.TransactionContextInterface, key string, value string) error {
+	return ctx.PutStateWithKYCLevel(key, []byte(value), kalpsdk.KYCLevelEnhanced)
+}
+
+func (c *kycContract) Delete(ctx kalpsdk.TransactionContextInterface, key string) error {
+	return ctx.DelStateWithKYC(key)
+}
+
+func (c *kycContract) PutPrivate(ctx kalpsdk.TransactionContextInterface, collection string, key string, value string) error {
+	return ctx.PutPrivateDataWithKYC(collection, key, []byte(value))
+}
+
+func TestKYCChaincode(t *testing.T) {
+	stub := NewMemStub("kalp")
+	kyc := RegisterKYCChaincode(stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetStub(stub)
+	stub.StartTransaction("tx1")
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		kycCheck, err := ctx.GetKYC("alice")
+		require.NoError(t, err)
+		require.True(t, kycCheck)
+
+		kycCheck, err = ctx.GetKYC("bob")
+		require.NoError(t, err)
+		require.False(t, kycCheck)
+	})
+
+	// Check that a created KYC record becomes visible once the transaction is committed
+	t.Run("Check PutKYC", func(t *testing.T) {
+		require.NoError(t, ctx.PutKYC("bob", "kyc2", "hash2"))
+		kycCheck, err := ctx.GetKYC("bob")
+		require.NoError(t, err)
+		require.False(t, kycCheck)
+
+		stub.CommitTransaction()
+		stub.StartTransaction("tx2")
+		kycCheck, err = ctx.GetKYC("bob")
+		require.NoError(t, err)
+		require.True(t, kycCheck)
+
+		record, err := kyc.GetRecord("bob")
+		require.NoError(t, err)
+		require.Equal(t, &KYCRecord{ID: "bob", KycID: "kyc2", KycHash: "hash2"}, record)
+
+		require.Error(t, ctx.PutKYC("bob", "kyc3", "hash3"))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		defer kyc.ResetResponses()
+
+		kyc.InjectResponse("KycExists", shim.Error("kyc unavailable"))
+		_, err := ctx.GetKYC("alice")
+		require.ErrorContains(t, err, "failed to query kyc chaincode for user alice. Got status 500")
+
+		kyc.InjectResponse("KycExists", shim.Success([]byte("maybe")))
+		_, err = ctx.GetKYC("alice")
+		require.Error(t, err)
+
+		kyc.InjectResponse("CreateKyc", shim.Error("kyc unavailable"))
+		require.Error(t, ctx.PutKYC("carol", "kyc4", "hash4"))
+	})
+
+	// Check that the chaincode is unreachable without registration
+	t.Run("Check for unregistered chaincode", func(t *testing.T) {
+		other := NewMemStub("kalp")
+		otherCtx := &kalpsdk.TransactionContext{}
+		otherCtx.SetStub(other)
+		_, err := otherCtx.GetKYC("alice")
+		require.Error(t, err)
+	})
+}
+
+func TestStateWithKYCThroughHarness(t *testing.T) {
+	harness, err := NewHarness(&kycContract{})
+	require.NoError(t, err)
+	kyc := RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+
+	alice, err := NewIdentity(IdentitySpec{CommonName: "alice", OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+	bob, err := NewIdentity(IdentitySpec{CommonName: "bob", OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, harness.SetIdentity(alice))
+		result := harness.Submit("Put", "color", "blue")
+		require.NoError(t, result.Err())
+		require.Equal(t, []KVWrite{{Key: "color", Value: []byte("blue")}}, result.WriteSet)
+
+		result = harness.Submit("Delete", "color")
+		require.NoError(t, result.Err())
+		require.Equal(t, []KVWrite{{Key: "color", IsDelete: true}}, result.WriteSet)
+
+		require.NoError(t, harness.Submit("PutPrivate", "org1", "price", "10").Err())
+		value, err := harness.Stub.GetPrivateData("org1", "price")
+		require.NoError(t, err)
+		require.Equal(t, "10", string(value))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.NoError(t, harness.SetIdentity(bob))
+		require.ErrorContains(t, harness.Submit("Put", "color", "red").Err(), "user bob has not completed KYC")
+		require.ErrorContains(t, harness.Submit("Delete", "color").Err(), "user bob has not completed KYC")
+		require.ErrorContains(t, harness.Submit("PutPrivate", "org1", "price", "5").Err(), "user bob has not completed KYC")
+
+		require.NoError(t, harness.SetIdentity(alice))
+		kyc.InjectResponse("KycExists", shim.Error("kyc unavailable"))
+		defer kyc.ResetResponses()
+		require.ErrorContains(t, harness.Submit("Put", "color", "red").Err(), "failed to perform KYC check for user alice")
+	})
+}
+
+func TestKYCConfigThroughHarness(t *testing.T) {
+	alice, err := NewIdentity(IdentitySpec{CommonName: "alice", OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+
+	// Check the KYC config set on the contract
+	t.Run("Check contract config", func(t *testing.T) {
+		config := kalpsdk.KYCConfig{ChaincodeName: "universalkyc", ChannelName: "kyc-channel", ExistsFunction: "IsVerified"}
+		contract := &kycContract{}
+		contract.KYCConfig = &config
+		harness, err := NewHarness(contract)
+		require.NoError(t, err)
+		require.NoError(t, harness.SetIdentity(alice))
+
+		// The default kyc chaincode is not consulted
+		require.NoError(t, RegisterKYCChaincode(harness.Stub).AddKYC("alice", "kyc1", "hash1"))
+		require.ErrorContains(t, harness.Submit("Put", "color", "blue").Err(), "failed to query kyc chaincode for user alice. Got status 500")
+
+		require.NoError(t, RegisterKYCChaincodeWithConfig(harness.Stub, config).AddKYC("alice", "kyc1", "hash1"))
+		require.NoError(t, harness.Submit("Put", "color", "blue").Err())
+	})
+
+	// Check the KYC config stored by the init function
+	t.Run("Check init config", func(t *testing.T) {
+		harness, err := NewHarness(&kycContract{})
+		require.NoError(t, err)
+		require.NoError(t, harness.SetIdentity(alice))
+		require.NoError(t, RegisterKYCChaincodeWithConfig(harness.Stub, kalpsdk.KYCConfig{ChaincodeName: "universalkyc"}).AddKYC("alice", "kyc1", "hash1"))
+
+		require.ErrorContains(t, harness.Submit("Put", "color", "blue").Err(), "failed to query kyc chaincode for user alice. Got status 500")
+
+		result := harness.Init(kalpsdk.KYCConfigInitFunction, `{"chaincodeName":"universalkyc"}`)
+		require.NoError(t, result.Err())
+		require.Len(t, result.WriteSet, 1)
+
+		require.NoError(t, harness.Submit("Put", "color", "blue").Err())
+
+		// The stored config is not returned by range queries
+		iterator, err := harness.Stub.GetStateByRange("", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"color"}, collectKeys(t, iterator))
+	})
+}
+
+func TestKYCCacheThroughHarness(t *testing.T) {
+	harness, err := NewHarness(&kycContract{})
+	require.NoError(t, err)
+	kyc := RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+
+	alice, err := NewIdentity(IdentitySpec{CommonName: "alice", OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+	require.NoError(t, harness.SetIdentity(alice))
+
+	// Check that the KYC chaincode is invoked once per transaction
+	result := harness.Submit("PutMany", `["a","b","c"]`)
+	require.NoError(t, result.Err())
+	require.Len(t, result.WriteSet, 3)
+	require.Equal(t, 1, kyc.Calls("KycExists"))
+
+	require.NoError(t, harness.Submit("PutMany", `["d","e"]`).Err())
+	require.Equal(t, 2, kyc.Calls("KycExists"))
+}
+
+func TestKYCProviderThroughHarness(t *testing.T) {
+	statuses := map[string]kalpsdk.KYCStatus{
+		"alice": {Level: kalpsdk.KYCLevelEnhanced, Country: "IN"},
+		"bob":   {Level: kalpsdk.KYCLevelBasic, Country: "US"},
+		"carol": {Level: kalpsdk.KYCLevelEnhanced, ExpiresAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	contract := &kycContract{}
+	contract.KYCProvider = kalpsdk.KYCProviderFunc(func(ctx kalpsdk.TransactionContextInterface, userID string) (kalpsdk.KYCStatus, error) {
+		return statuses[userID], nil
+	})
+	harness, err := NewHarness(contract)
+	require.NoError(t, err)
+
+	submitAs := func(name string, function string, args ...string) *Result {
+		id, err := NewIdentity(IdentitySpec{CommonName: name, OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+		require.NoError(t, err)
+		require.NoError(t, harness.SetIdentity(id))
+		return harness.Submit(function, args...)
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, submitAs("alice", "PutEnhanced", "color", "blue").Err())
+		require.NoError(t, submitAs("bob", "Put", "color", "red").Err())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.ErrorContains(t, submitAs("bob", "PutEnhanced", "color", "red").Err(), "user bob has KYC level basic, but enhanced is required")
+		require.ErrorContains(t, submitAs("carol", "Put", "color", "red").Err(), "KYC of user carol expired at 2020-01-01T00:00:00Z")
+		require.ErrorContains(t, submitAs("dave", "Put", "color", "red").Err(), "user dave has not completed KYC")
+	})
+}
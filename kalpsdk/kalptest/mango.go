@@ -0,0 +1,652 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+)
+
+// mangoQuery is a CouchDB Mango query as accepted by GetQueryResult.
+// The use_index and bookmark members are accepted but have no effect on the in-memory evaluation.
+type mangoQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Sort     []interface{}          `json:"sort"`
+	Limit    *int                   `json:"limit"`
+	Skip     int                    `json:"skip"`
+	Fields   []string               `json:"fields"`
+	UseIndex interface{}            `json:"use_index"`
+	Bookmark string                 `json:"bookmark"`
+}
+
+// sortField is a single member of the sort specification of a query.
+type sortField struct {
+	path []string
+	desc bool
+}
+
+// parseMangoQuery parses and validates a Mango query string.
+//
+// Parameters:
+//   - query: The query in CouchDB Mango JSON syntax.
+//
+// Returns:
+//   - *mangoQuery: The parsed query.
+//   - error: An error if the query is not valid JSON, has no selector or uses an unknown operator.
+func parseMangoQuery(query string) (*mangoQuery, error) {
+	var q mangoQuery
+	if err := json.Unmarshal([]byte(query), &q); err != nil {
+		return nil, fmt.Errorf("invalid query %q: %v", query, err)
+	}
+	if q.Selector == nil {
+		return nil, fmt.Errorf("invalid query %q: missing required key: selector", query)
+	}
+	if q.Limit != nil && *q.Limit < 0 {
+		return nil, fmt.Errorf("invalid query %q: limit must be a non-negative integer", query)
+	}
+	if q.Skip < 0 {
+		return nil, fmt.Errorf("invalid query %q: skip must be a non-negative integer", query)
+	}
+	// Evaluate the selector once against an empty document so that unknown operators and malformed
+	// arguments are reported even when the world state is empty.
+	if _, err := matchSelector(q.Selector, map[string]interface{}{}); err != nil {
+		return nil, err
+	}
+	if _, err := q.sortFields(); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// sortFields returns the parsed sort specification of the query.
+func (q *mangoQuery) sortFields() ([]sortField, error) {
+	fields := make([]sortField, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		switch v := s.(type) {
+		case string:
+			fields = append(fields, sortField{path: splitFieldPath(v)})
+		case map[string]interface{}:
+			if len(v) != 1 {
+				return nil, fmt.Errorf("invalid sort field %v: expected a single field", v)
+			}
+			for name, dir := range v {
+				switch dir {
+				case "asc":
+					fields = append(fields, sortField{path: splitFieldPath(name)})
+				case "desc":
+					fields = append(fields, sortField{path: splitFieldPath(name), desc: true})
+				default:
+					return nil, fmt.Errorf("invalid sort direction %v for field %s", dir, name)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid sort field %v", s)
+		}
+	}
+	return fields, nil
+}
+
+// execute runs the query over the given key/value pairs, which must be in key order. Values that are not JSON
+// objects are skipped, as the peer does not index them in CouchDB. Documents are returned in key order unless a
+// sort is given, in which case documents lacking one of the sort fields are left out.
+//
+// Parameters:
+//   - kvs: The world state to query.
+//
+// Returns:
+//   - []*queryresult.KV: The matching entries after sort, skip, limit and fields projection.
+//   - error: An error if the selector cannot be evaluated.
+func (q *mangoQuery) execute(kvs []*queryresult.KV) ([]*queryresult.KV, error) {
+	type match struct {
+		kv  *queryresult.KV
+		doc map[string]interface{}
+	}
+
+	sortFields, err := q.sortFields()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []match{}
+	for _, kv := range kvs {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(kv.Value, &doc); err != nil || doc == nil {
+			continue
+		}
+		doc["_id"] = kv.Key
+
+		ok, err := matchSelector(q.Selector, doc)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !hasFields(doc, sortFields) {
+			continue
+		}
+		matches = append(matches, match{kv: kv, doc: doc})
+	}
+
+	if len(sortFields) > 0 {
+		sort.SliceStable(matches, func(i, j int) bool {
+			for _, f := range sortFields {
+				a, _ := getField(matches[i].doc, f.path)
+				b, _ := getField(matches[j].doc, f.path)
+				c := collate(a, b)
+				if c == 0 {
+					continue
+				}
+				if f.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+
+	if q.Skip >= len(matches) {
+		matches = matches[:0]
+	} else {
+		matches = matches[q.Skip:]
+	}
+	if q.Limit != nil && *q.Limit < len(matches) {
+		matches = matches[:*q.Limit]
+	}
+
+	results := make([]*queryresult.KV, 0, len(matches))
+	for _, m := range matches {
+		if len(q.Fields) == 0 {
+			results = append(results, m.kv)
+			continue
+		}
+		value, err := json.Marshal(projectFields(m.doc, q.Fields))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal projected document %s: %v", m.kv.Key, err)
+		}
+		results = append(results, &queryresult.KV{Namespace: m.kv.Namespace, Key: m.kv.Key, Value: value})
+	}
+	return results, nil
+}
+
+// matchSelector reports whether the document matches every member of the selector.
+func matchSelector(selector map[string]interface{}, doc interface{}) (bool, error) {
+	matched := true
+	for key, cond := range selector {
+		var ok bool
+		var err error
+		if strings.HasPrefix(key, "$") {
+			ok, err = matchCombination(key, cond, doc)
+		} else {
+			value, found := getField(doc, splitFieldPath(key))
+			ok, err = matchCondition(cond, value, found)
+		}
+		if err != nil {
+			return false, err
+		}
+		// Keep evaluating so that invalid operators are always reported.
+		matched = matched && ok
+	}
+	return matched, nil
+}
+
+// matchCombination evaluates a top-level $and, $or, $nor or $not whose arguments are selectors.
+func matchCombination(op string, arg interface{}, doc interface{}) (bool, error) {
+	if op == "$not" {
+		sel, ok := arg.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid argument for %s: expected an object", op)
+		}
+		ok, err := matchSelector(sel, doc)
+		return !ok, err
+	}
+
+	args, ok := arg.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("invalid argument for %s: expected an array", op)
+	}
+	results := make([]bool, 0, len(args))
+	for _, a := range args {
+		sel, ok := a.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid argument for %s: expected an array of objects", op)
+		}
+		ok, err := matchSelector(sel, doc)
+		if err != nil {
+			return false, err
+		}
+		results = append(results, ok)
+	}
+	return combine(op, results)
+}
+
+// matchCondition reports whether a field value satisfies a condition. A condition is either an object of operators,
+// an object without operators acting as a selector on a sub-document, or any other value compared with $eq.
+// Missing fields only satisfy {"$exists": false}.
+func matchCondition(cond interface{}, value interface{}, found bool) (bool, error) {
+	ops, isObject := cond.(map[string]interface{})
+	if !isObject {
+		return found && collate(value, cond) == 0, nil
+	}
+
+	hasOperators := false
+	for key := range ops {
+		if strings.HasPrefix(key, "$") {
+			hasOperators = true
+		} else if hasOperators {
+			return false, fmt.Errorf("invalid condition %v: cannot mix operators and fields", cond)
+		}
+	}
+	if !hasOperators {
+		if len(ops) == 0 {
+			return found && collate(value, cond) == 0, nil
+		}
+		return matchSelector(ops, value)
+	}
+
+	matched := true
+	for op, arg := range ops {
+		ok, err := matchOperator(op, arg, value, found)
+		if err != nil {
+			return false, err
+		}
+		matched = matched && ok
+	}
+	return matched, nil
+}
+
+// matchOperator evaluates a single condition operator against a field value.
+func matchOperator(op string, arg interface{}, value interface{}, found bool) (bool, error) {
+	switch op {
+	case "$and", "$or", "$nor":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid argument for %s: expected an array", op)
+		}
+		results := make([]bool, 0, len(args))
+		for _, a := range args {
+			ok, err := matchCondition(a, value, found)
+			if err != nil {
+				return false, err
+			}
+			results = append(results, ok)
+		}
+		return combine(op, results)
+	case "$not":
+		ok, err := matchCondition(arg, value, found)
+		return found && !ok, err
+	case "$exists":
+		want, ok := arg.(bool)
+		if !ok {
+			return false, fmt.Errorf("invalid argument for $exists: expected a boolean")
+		}
+		return found == want, nil
+	}
+
+	if !found {
+		// The argument is still validated so that errors do not depend on the data.
+		_, err := matchOperator(op, arg, nil, true)
+		return false, err
+	}
+
+	switch op {
+	case "$eq":
+		return collate(value, arg) == 0, nil
+	case "$ne":
+		return collate(value, arg) != 0, nil
+	case "$lt":
+		return collate(value, arg) < 0, nil
+	case "$lte":
+		return collate(value, arg) <= 0, nil
+	case "$gt":
+		return collate(value, arg) > 0, nil
+	case "$gte":
+		return collate(value, arg) >= 0, nil
+	case "$in", "$nin":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid argument for %s: expected an array", op)
+		}
+		in := false
+		values, isArray := value.([]interface{})
+		if !isArray {
+			values = []interface{}{value}
+		}
+		for _, a := range args {
+			for _, v := range values {
+				in = in || collate(v, a) == 0
+			}
+		}
+		return in == (op == "$in"), nil
+	case "$all":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid argument for $all: expected an array")
+		}
+		values, isArray := value.([]interface{})
+		if !isArray {
+			return false, nil
+		}
+		for _, a := range args {
+			if !containsValue(values, a) {
+				return false, nil
+			}
+		}
+		return len(args) > 0, nil
+	case "$size":
+		size, ok := arg.(float64)
+		if !ok || size != math.Trunc(size) {
+			return false, fmt.Errorf("invalid argument for $size: expected an integer")
+		}
+		values, isArray := value.([]interface{})
+		return isArray && len(values) == int(size), nil
+	case "$type":
+		name, ok := arg.(string)
+		if !ok {
+			return false, fmt.Errorf("invalid argument for $type: expected a string")
+		}
+		switch name {
+		case "null", "boolean", "number", "string", "array", "object":
+			return typeName(value) == name, nil
+		}
+		return false, fmt.Errorf("invalid argument for $type: unknown type %s", name)
+	case "$mod":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) != 2 {
+			return false, fmt.Errorf("invalid argument for $mod: expected [divisor, remainder]")
+		}
+		divisor, ok1 := args[0].(float64)
+		remainder, ok2 := args[1].(float64)
+		if !ok1 || !ok2 || divisor != math.Trunc(divisor) || remainder != math.Trunc(remainder) || divisor == 0 {
+			return false, fmt.Errorf("invalid argument for $mod: divisor and remainder must be integers and divisor must not be 0")
+		}
+		n, isNumber := value.(float64)
+		return isNumber && n == math.Trunc(n) && math.Mod(n, divisor) == remainder, nil
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return false, fmt.Errorf("invalid argument for $regex: expected a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid argument for $regex: %v", err)
+		}
+		s, isString := value.(string)
+		return isString && re.MatchString(s), nil
+	case "$elemMatch", "$allMatch":
+		if _, ok := arg.(map[string]interface{}); !ok {
+			return false, fmt.Errorf("invalid argument for %s: expected an object", op)
+		}
+		values, isArray := value.([]interface{})
+		if !isArray {
+			return false, nil
+		}
+		// Validate the argument even when the array is empty.
+		if _, err := matchCondition(arg, nil, true); err != nil {
+			return false, err
+		}
+		for _, v := range values {
+			ok, err := matchCondition(arg, v, true)
+			if err != nil {
+				return false, err
+			}
+			if ok && op == "$elemMatch" {
+				return true, nil
+			}
+			if !ok && op == "$allMatch" {
+				return false, nil
+			}
+		}
+		return op == "$allMatch" && len(values) > 0, nil
+	case "$keyMapMatch":
+		if _, ok := arg.(map[string]interface{}); !ok {
+			return false, fmt.Errorf("invalid argument for $keyMapMatch: expected an object")
+		}
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return false, nil
+		}
+		for key := range object {
+			ok, err := matchCondition(arg, key, true)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid operator: %s", op)
+}
+
+// combine folds the results of the arguments of $and, $or or $nor.
+func combine(op string, results []bool) (bool, error) {
+	switch op {
+	case "$and":
+		for _, r := range results {
+			if !r {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "$or":
+		for _, r := range results {
+			if r {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$nor":
+		for _, r := range results {
+			if r {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid operator: %s", op)
+}
+
+// splitFieldPath splits a dotted field name into its path. A dot preceded by a backslash is part of the name.
+func splitFieldPath(field string) []string {
+	path := []string{}
+	var current strings.Builder
+	for i := 0; i < len(field); i++ {
+		switch {
+		case field[i] == '\\' && i+1 < len(field) && field[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case field[i] == '.':
+			path = append(path, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(field[i])
+		}
+	}
+	return append(path, current.String())
+}
+
+// getField returns the value at the given path of a document and whether it exists.
+func getField(doc interface{}, path []string) (interface{}, bool) {
+	current := doc
+	for _, name := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[name]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// hasFields reports whether the document has every sort field.
+func hasFields(doc map[string]interface{}, fields []sortField) bool {
+	for _, f := range fields {
+		if _, ok := getField(doc, f.path); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// projectFields returns a copy of the document holding only the given fields.
+func projectFields(doc map[string]interface{}, fields []string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, field := range fields {
+		path := splitFieldPath(field)
+		value, ok := getField(doc, path)
+		if !ok || (len(path) == 1 && path[0] == "_id") {
+			continue
+		}
+		target := projected
+		for _, name := range path[:len(path)-1] {
+			next, ok := target[name].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				target[name] = next
+			}
+			target = next
+		}
+		target[path[len(path)-1]] = value
+	}
+	return projected
+}
+
+// containsValue reports whether any of the values collates equal to v.
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if collate(value, v) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the JSON type name of a decoded value as used by $type.
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// collationRank orders the JSON types the way CouchDB collates them: null, false, true, numbers, strings,
+// arrays and objects.
+func collationRank(v interface{}) int {
+	switch t := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !t {
+			return 1
+		}
+		return 2
+	case float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	}
+	return 6
+}
+
+// collate compares two decoded JSON values following CouchDB view collation and returns -1, 0 or 1.
+func collate(a, b interface{}) int {
+	ra, rb := collationRank(a), collationRank(b)
+	if ra != rb {
+		return compareInts(ra, rb)
+	}
+	switch av := a.(type) {
+	case float64:
+		bv := b.(float64)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case string:
+		return collateStrings(av, b.(string))
+	case []interface{}:
+		bv := b.([]interface{})
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := collate(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(av), len(bv))
+	case map[string]interface{}:
+		bv := b.(map[string]interface{})
+		ak, bk := sortedKeys(av), sortedKeys(bv)
+		for i := 0; i < len(ak) && i < len(bk); i++ {
+			if c := collateStrings(ak[i], bk[i]); c != 0 {
+				return c
+			}
+			if c := collate(av[ak[i]], bv[bk[i]]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(ak), len(bk))
+	}
+	return 0
+}
+
+// collateStrings approximates the ICU collation used by CouchDB: strings are compared case-insensitively first,
+// and lowercase sorts before uppercase when they only differ in case.
+func collateStrings(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	ar, br := []rune(a), []rune(b)
+	for i := 0; i < len(ar) && i < len(br); i++ {
+		if ar[i] == br[i] {
+			continue
+		}
+		if unicode.IsLower(ar[i]) && !unicode.IsLower(br[i]) {
+			return -1
+		}
+		if !unicode.IsLower(ar[i]) && unicode.IsLower(br[i]) {
+			return 1
+		}
+		return compareInts(int(ar[i]), int(br[i]))
+	}
+	return compareInts(len(ar), len(br))
+}
+
+// compareInts returns -1, 0 or 1 depending on whether a is less than, equal to or greater than b.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sortedKeys returns the keys of the object in lexical order.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
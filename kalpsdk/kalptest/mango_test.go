@@ -0,0 +1,144 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryStub returns a stub seeded with a few NIU-like documents and a non-JSON value.
+func newQueryStub() *MemStub {
+	stub := NewMemStub("kalp")
+	stub.SetCommittedState("asset1", []byte(`{"id":"asset1","docType":"NIU","status":"COMPLETED","amount":10,"account":["alice"],"metadata":{"country":"IN","tags":[{"name":"art","score":5}]}}`))
+	stub.SetCommittedState("asset2", []byte(`{"id":"asset2","docType":"NIU","status":"INPROGRESS","amount":25,"account":["bob","carol"],"metadata":{"country":"US","tags":[{"name":"music","score":2}]}}`))
+	stub.SetCommittedState("asset3", []byte(`{"id":"asset3","docType":"ASSET-NIU","status":"COMPLETED","amount":5,"account":["alice","bob"]}`))
+	stub.SetCommittedState("name", []byte("Kalp Token"))
+	return stub
+}
+
+func TestGetQueryResultSelectors(t *testing.T) {
+	stub := newQueryStub()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"Check implicit $eq and $and", `{"selector":{"docType":"NIU","status":"COMPLETED"}}`, []string{"asset1"}},
+		{"Check $gt", `{"selector":{"amount":{"$gt":5}}}`, []string{"asset1", "asset2"}},
+		{"Check $gte and $lt", `{"selector":{"amount":{"$gte":5,"$lt":25}}}`, []string{"asset1", "asset3"}},
+		{"Check $ne", `{"selector":{"status":{"$ne":"COMPLETED"}}}`, []string{"asset2"}},
+		{"Check $in on scalar", `{"selector":{"status":{"$in":["INPROGRESS","DRAFT"]}}}`, []string{"asset2"}},
+		{"Check $in on array", `{"selector":{"account":{"$in":["carol"]}}}`, []string{"asset2"}},
+		{"Check $nin", `{"selector":{"docType":{"$nin":["NIU"]}}}`, []string{"asset3"}},
+		{"Check $or", `{"selector":{"$or":[{"amount":5},{"status":"INPROGRESS"}]}}`, []string{"asset2", "asset3"}},
+		{"Check $nor", `{"selector":{"$nor":[{"amount":5},{"status":"INPROGRESS"}]}}`, []string{"asset1"}},
+		{"Check $not", `{"selector":{"$not":{"docType":"NIU"}}}`, []string{"asset3"}},
+		{"Check $regex", `{"selector":{"docType":{"$regex":"^ASSET-"}}}`, []string{"asset3"}},
+		{"Check $exists", `{"selector":{"metadata":{"$exists":false}}}`, []string{"asset3"}},
+		{"Check dotted field", `{"selector":{"metadata.country":"US"}}`, []string{"asset2"}},
+		{"Check nested field", `{"selector":{"metadata":{"country":"IN"}}}`, []string{"asset1"}},
+		{"Check $elemMatch", `{"selector":{"metadata.tags":{"$elemMatch":{"name":"art","score":{"$gte":5}}}}}`, []string{"asset1"}},
+		{"Check $all", `{"selector":{"account":{"$all":["alice","bob"]}}}`, []string{"asset3"}},
+		{"Check $size", `{"selector":{"account":{"$size":2}}}`, []string{"asset2", "asset3"}},
+		{"Check $type", `{"selector":{"metadata":{"$type":"object"}}}`, []string{"asset1", "asset2"}},
+		{"Check $mod", `{"selector":{"amount":{"$mod":[5,0]}}}`, []string{"asset1", "asset2", "asset3"}},
+		{"Check _id", `{"selector":{"_id":{"$gt":"asset2"}}}`, []string{"asset3"}},
+		{"Check missing field does not match", `{"selector":{"owner":{"$ne":"alice"}}}`, []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			iterator, err := stub.GetQueryResult(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, collectKeys(t, iterator))
+		})
+	}
+}
+
+func TestGetQueryResultSortLimitFields(t *testing.T) {
+	stub := newQueryStub()
+
+	// Check sort, skip and limit
+	t.Run("Check sort, skip and limit", func(t *testing.T) {
+		iterator, err := stub.GetQueryResult(`{"selector":{"amount":{"$gt":0}},"sort":[{"amount":"desc"}],"skip":1,"limit":1}`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"asset1"}, collectKeys(t, iterator))
+	})
+
+	// Check that documents without the sort field are left out
+	t.Run("Check sort on missing field", func(t *testing.T) {
+		iterator, err := stub.GetQueryResult(`{"selector":{"amount":{"$gt":0}},"sort":["metadata.country"]}`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"asset1", "asset2"}, collectKeys(t, iterator))
+	})
+
+	// Check fields projection
+	t.Run("Check fields", func(t *testing.T) {
+		iterator, err := stub.GetQueryResult(`{"selector":{"id":"asset2"},"fields":["id","metadata.country"]}`)
+		require.NoError(t, err)
+
+		kv, err := iterator.Next()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"asset2","metadata":{"country":"US"}}`, string(kv.Value))
+		require.False(t, iterator.HasNext())
+	})
+}
+
+func TestGetQueryResultErrors(t *testing.T) {
+	stub := NewMemStub("kalp")
+
+	for _, query := range []string{
+		`not json`,
+		`{"limit":1}`,
+		`{"selector":{"amount":{"$foo":1}}}`,
+		`{"selector":{"$or":{"amount":1}}}`,
+		`{"selector":{"amount":{"$in":1}}}`,
+		`{"selector":{"id":{"$regex":"("}}}`,
+		`{"selector":{"id":"x"},"sort":[{"id":"up"}]}`,
+	} {
+		_, err := stub.GetQueryResult(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestIsMintedWithMemStub(t *testing.T) {
+	stub := newQueryStub()
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetStub(stub)
+
+	minted, err := kalpsdk.IsMinted(ctx, "asset1", "NIU")
+	require.NoError(t, err)
+	require.True(t, minted)
+
+	minted, err = kalpsdk.IsMinted(ctx, "asset1", "ASSET-NIU")
+	require.NoError(t, err)
+	require.False(t, minted)
+
+	// Check that ids containing quotes cannot change the selector
+	minted, err = kalpsdk.IsMinted(ctx, `x", "docType": {"$ne": ""}, "id": {"$gt": "`, "NIU")
+	require.NoError(t, err)
+	require.False(t, minted)
+}
+
+func TestQueryBuilderWithMemStub(t *testing.T) {
+	stub := newQueryStub()
+
+	q, err := query.New(query.Selector{"docType": "NIU"}).
+		Where("account", query.ElemMatch(query.Eq("bob"))).
+		Where("metadata.country", query.Exists(true)).
+		Sort("amount", query.Desc).
+		Fields("id").
+		Build()
+	require.NoError(t, err)
+
+	iterator, err := stub.GetQueryResult(q)
+	require.NoError(t, err)
+	require.Equal(t, []string{"asset2"}, collectKeys(t, iterator))
+}
@@ -0,0 +1,720 @@
+// Package kalptest provides in-memory stand-ins for the pieces of a Kalptantra peer that a contract built with
+// kalpsdk talks to, so that contracts can be exercised end to end in plain Go unit tests without hand-scripted
+// mock expectations and without a running network.
+//
+// The central type is MemStub, an implementation of shim.ChaincodeStubInterface that keeps the world state in
+// memory and follows the same read/write semantics as the peer: reads are served from the committed state, writes
+// are collected in a write set and only become visible once the transaction is committed.
+package kalptest
+
+import (
+	//Standard Libs
+	"crypto/sha256"
+	"fmt"
+	"unicode/utf8"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	compositeKeyNamespace = "\x00"
+	minUnicodeRuneValue   = 0            // U+0000
+	maxUnicodeRuneValue   = utf8.MaxRune // U+10FFFF - maximum (and unallocated) code point
+	emptyKeySubstitute    = "\x01"
+)
+
+// KVWrite is a single entry of a transaction's write set.
+type KVWrite struct {
+	Key      string // The key being written or deleted.
+	Value    []byte // The new value, nil for deletes.
+	IsDelete bool   // True if the key is deleted by the transaction.
+}
+
+// MemStub is an in-memory implementation of shim.ChaincodeStubInterface.
+//
+// The committed world state is kept in a sorted map so that range and partial composite key queries return keys in
+// lexical order, exactly like the peer. PutState and DelState only record the change in the write set of the current
+// transaction; GetState and the query functions keep returning the committed state until CommitTransaction is called.
+// Every committed write is recorded in the key history returned by GetHistoryForKey. Private data collections follow
+// the same rules, each with its own state and write set.
+type MemStub struct {
+	channelID   string
+	txID        string
+	txTimestamp *timestamppb.Timestamp
+	args        [][]byte
+	creator     []byte
+	transient   map[string][]byte
+	decorations map[string][]byte
+	proposal    *pb.SignedProposal
+	event       *pb.ChaincodeEvent
+
+	state            *sortedState
+	writes           map[string]KVWrite
+	history          map[string][]*queryresult.KeyModification
+	validationParams map[string][]byte
+	collections      map[string]*privateCollection
+	chaincodes       map[string]*registeredChaincode
+}
+
+// registeredChaincode is a chaincode reachable through InvokeChaincode, together with the stub holding its
+// world state.
+type registeredChaincode struct {
+	chaincode shim.Chaincode
+	stub      *MemStub
+}
+
+// NewMemStub creates an empty in-memory stub bound to the given channel.
+//
+// Parameters:
+//   - channelID: The channel returned by GetChannelID.
+//
+// Returns:
+//   - *MemStub: The initialized stub with an empty world state.
+func NewMemStub(channelID string) *MemStub {
+	return &MemStub{
+		channelID:        channelID,
+		decorations:      make(map[string][]byte),
+		state:            newSortedState(),
+		writes:           make(map[string]KVWrite),
+		history:          make(map[string][]*queryresult.KeyModification),
+		validationParams: make(map[string][]byte),
+		collections:      make(map[string]*privateCollection),
+		chaincodes:       make(map[string]*registeredChaincode),
+	}
+}
+
+// RegisterChaincode makes a chaincode reachable through InvokeChaincode. The chaincode gets its own world state,
+// kept in a separate MemStub, that takes part in the transactions of this stub: invocations on the same channel
+// are committed or aborted together with the calling transaction, while invocations on another channel behave
+// like queries and their writes are always discarded.
+//
+// Parameters:
+//   - name: The chaincode name passed to InvokeChaincode.
+//   - channel: The channel the chaincode is deployed on. If empty, the channel of this stub is used.
+//   - cc: The chaincode handling the invocations.
+//
+// Returns:
+//   - *MemStub: The stub holding the world state of the registered chaincode.
+func (s *MemStub) RegisterChaincode(name, channel string, cc shim.Chaincode) *MemStub {
+	if channel == "" {
+		channel = s.channelID
+	}
+	stub := NewMemStub(channel)
+	s.chaincodes[chaincodeKey(name, channel)] = &registeredChaincode{chaincode: cc, stub: stub}
+	return stub
+}
+
+// StartTransaction begins a new transaction with the given ID. Any uncommitted writes, the pending event and the
+// transient map of a previous transaction are discarded, and the transaction timestamp is set to the current time.
+//
+// Parameters:
+//   - txID: The transaction ID returned by GetTxID.
+func (s *MemStub) StartTransaction(txID string) {
+	s.txID = txID
+	s.txTimestamp = timestamppb.Now()
+	s.writes = make(map[string]KVWrite)
+	s.event = nil
+	s.transient = nil
+	for _, c := range s.collections {
+		c.writes = make(map[string]KVWrite)
+	}
+	for _, rc := range s.chaincodes {
+		rc.stub.StartTransaction(txID)
+		rc.stub.txTimestamp = s.txTimestamp
+	}
+}
+
+// CommitTransaction applies the write set of the current transaction to the committed world state and records each
+// write in the history of its key. The private data writes are applied to their collections.
+//
+// Returns:
+//   - []KVWrite: The committed write set, sorted by key.
+func (s *MemStub) CommitTransaction() []KVWrite {
+	writeSet := s.WriteSet()
+	for _, w := range writeSet {
+		if w.IsDelete {
+			s.state.delete(w.Key)
+		} else {
+			s.state.put(w.Key, w.Value)
+		}
+		s.history[w.Key] = append(s.history[w.Key], &queryresult.KeyModification{
+			TxId:      s.txID,
+			Value:     w.Value,
+			Timestamp: s.txTimestamp,
+			IsDelete:  w.IsDelete,
+		})
+	}
+	s.writes = make(map[string]KVWrite)
+	for _, c := range s.collections {
+		c.commit()
+	}
+	for _, rc := range s.chaincodes {
+		rc.stub.CommitTransaction()
+	}
+	return writeSet
+}
+
+// AbortTransaction discards the write set of the current transaction without touching the committed world state.
+func (s *MemStub) AbortTransaction() {
+	s.writes = make(map[string]KVWrite)
+	for _, c := range s.collections {
+		c.writes = make(map[string]KVWrite)
+	}
+	for _, rc := range s.chaincodes {
+		rc.stub.AbortTransaction()
+	}
+}
+
+// WriteSet returns the uncommitted writes of the current transaction, sorted by key.
+//
+// Returns:
+//   - []KVWrite: The pending write set.
+func (s *MemStub) WriteSet() []KVWrite {
+	return sortedWrites(s.writes)
+}
+
+// SetCommittedState writes `key` and `value` straight into the committed world state, bypassing the write set and
+// the key history. It is meant for seeding the ledger before a test runs.
+//
+// Parameters:
+//   - key: The key to seed.
+//   - value: The value stored under the key. A nil value removes the key.
+func (s *MemStub) SetCommittedState(key string, value []byte) {
+	if value == nil {
+		s.state.delete(key)
+		return
+	}
+	s.state.put(key, value)
+}
+
+// PrivateWriteSet returns the uncommitted writes of the current transaction to the given private data collection,
+// sorted by key.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//
+// Returns:
+//   - []KVWrite: The pending write set of the collection.
+func (s *MemStub) PrivateWriteSet(collection string) []KVWrite {
+	c, ok := s.collections[collection]
+	if !ok {
+		return []KVWrite{}
+	}
+	return sortedWrites(c.writes)
+}
+
+// SetCommittedPrivateData writes `key` and `value` straight into the committed state of a private data collection,
+// bypassing the write set. It is meant for seeding the ledger before a test runs.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key to seed.
+//   - value: The value stored under the key. A nil value removes the key.
+func (s *MemStub) SetCommittedPrivateData(collection string, key string, value []byte) {
+	c := s.collection(collection)
+	if value == nil {
+		c.state.delete(key)
+		return
+	}
+	c.state.put(key, value)
+}
+
+// SetArgs sets the raw arguments of the current transaction, the first one being the function name.
+func (s *MemStub) SetArgs(args [][]byte) {
+	s.args = args
+}
+
+// SetFunctionAndParameters sets the function name and string parameters of the current transaction.
+func (s *MemStub) SetFunctionAndParameters(function string, params ...string) {
+	args := make([][]byte, 0, len(params)+1)
+	args = append(args, []byte(function))
+	for _, p := range params {
+		args = append(args, []byte(p))
+	}
+	s.args = args
+}
+
+// SetCreator sets the serialized identity (a marshaled msp.SerializedIdentity) returned by GetCreator.
+func (s *MemStub) SetCreator(creator []byte) {
+	s.creator = creator
+}
+
+// SetTransient sets the transient map of the current transaction.
+func (s *MemStub) SetTransient(transient map[string][]byte) {
+	s.transient = transient
+}
+
+// SetTxTimestamp overrides the timestamp of the current transaction.
+func (s *MemStub) SetTxTimestamp(ts *timestamppb.Timestamp) {
+	s.txTimestamp = ts
+}
+
+// SetSignedProposal sets the signed proposal returned by GetSignedProposal.
+func (s *MemStub) SetSignedProposal(proposal *pb.SignedProposal) {
+	s.proposal = proposal
+}
+
+// Event returns the event set by the current transaction, or nil if no event was set.
+func (s *MemStub) Event() *pb.ChaincodeEvent {
+	return s.event
+}
+
+// GetArgs returns the arguments of the current transaction.
+func (s *MemStub) GetArgs() [][]byte {
+	return s.args
+}
+
+// GetStringArgs returns the arguments of the current transaction as strings.
+func (s *MemStub) GetStringArgs() []string {
+	strargs := make([]string, 0, len(s.args))
+	for _, barg := range s.args {
+		strargs = append(strargs, string(barg))
+	}
+	return strargs
+}
+
+// GetFunctionAndParameters returns the first argument as the function name and the rest of the arguments as
+// parameters.
+func (s *MemStub) GetFunctionAndParameters() (string, []string) {
+	allargs := s.GetStringArgs()
+	if len(allargs) == 0 {
+		return "", []string{}
+	}
+	return allargs[0], allargs[1:]
+}
+
+// GetArgsSlice returns the arguments of the current transaction concatenated into a single byte slice.
+func (s *MemStub) GetArgsSlice() ([]byte, error) {
+	res := []byte{}
+	for _, barg := range s.args {
+		res = append(res, barg...)
+	}
+	return res, nil
+}
+
+// GetTxID returns the ID of the current transaction.
+func (s *MemStub) GetTxID() string {
+	return s.txID
+}
+
+// GetChannelID returns the channel the stub is bound to.
+func (s *MemStub) GetChannelID() string {
+	return s.channelID
+}
+
+// InvokeChaincode calls a chaincode registered with RegisterChaincode within the current transaction. If the
+// `channel` parameter is empty, the channel of the stub is used. Invoking a chaincode that is not registered
+// returns an error response.
+func (s *MemStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	if channel == "" {
+		channel = s.channelID
+	}
+	rc, ok := s.chaincodes[chaincodeKey(chaincodeName, channel)]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not available on channel %s", chaincodeName, channel))
+	}
+
+	rc.stub.txID = s.txID
+	rc.stub.args = args
+	rc.stub.creator = s.creator
+	rc.stub.transient = s.transient
+	response := rc.chaincode.Invoke(rc.stub)
+
+	// Chaincodes on other channels are only queried, their writes never reach the ledger.
+	if channel != s.channelID {
+		rc.stub.AbortTransaction()
+	}
+	return response
+}
+
+// GetState returns the committed value of `key`. Writes of the current transaction are not visible.
+// If the key does not exist, (nil, nil) is returned.
+func (s *MemStub) GetState(key string) ([]byte, error) {
+	return s.state.get(key), nil
+}
+
+// PutState records `key` and `value` in the write set of the current transaction.
+func (s *MemStub) PutState(key string, value []byte) error {
+	if key == "" {
+		return fmt.Errorf("key must not be an empty string")
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	s.writes[key] = KVWrite{Key: key, Value: append([]byte(nil), value...)}
+	return nil
+}
+
+// DelState records the deletion of `key` in the write set of the current transaction.
+func (s *MemStub) DelState(key string) error {
+	s.writes[key] = KVWrite{Key: key, IsDelete: true}
+	return nil
+}
+
+// SetStateValidationParameter sets the key-level endorsement policy of `key`.
+func (s *MemStub) SetStateValidationParameter(key string, ep []byte) error {
+	s.validationParams[key] = ep
+	return nil
+}
+
+// GetStateValidationParameter returns the key-level endorsement policy of `key`, or nil if none is set.
+func (s *MemStub) GetStateValidationParameter(key string) ([]byte, error) {
+	return s.validationParams[key], nil
+}
+
+// GetStateByRange returns an iterator over the committed keys between startKey (inclusive) and endKey (exclusive),
+// in lexical order. Empty keys denote an unbounded range. Composite keys are never returned.
+func (s *MemStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return newStateIterator(s.state.rangeKVs(startKey, endKey)), nil
+}
+
+// GetStateByRangeWithPagination is GetStateByRange returning at most `pageSize` keys, starting at `bookmark` if it
+// is set. The bookmark of the next page is the next key of the range, or empty once the range is exhausted. A
+// `pageSize` of zero or less returns every remaining key.
+func (s *MemStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey, bookmark); err != nil {
+		return nil, nil, err
+	}
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	iterator, metadata := paginate(s.state.rangeKVs(startKey, endKey), pageSize)
+	return iterator, metadata, nil
+}
+
+// GetStateByPartialCompositeKey returns an iterator over the committed composite keys whose prefix matches the
+// given object type and attributes.
+func (s *MemStub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	startKey, endKey, err := createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	return newStateIterator(s.state.rangeKVs(startKey, endKey)), nil
+}
+
+// GetStateByPartialCompositeKeyWithPagination is GetStateByPartialCompositeKey returning at most `pageSize` keys,
+// starting at `bookmark` if it is set. The bookmark of the next page is the next matching key, or empty once every
+// matching key has been returned.
+func (s *MemStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
+	pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	startKey, endKey, err := createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bookmark != "" {
+		if bookmark < startKey || bookmark >= endKey {
+			return nil, nil, fmt.Errorf("bookmark %q does not match the partial composite key", bookmark)
+		}
+		startKey = bookmark
+	}
+	iterator, metadata := paginate(s.state.rangeKVs(startKey, endKey), pageSize)
+	return iterator, metadata, nil
+}
+
+// CreateCompositeKey combines the given object type and attributes into a composite key.
+func (s *MemStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return shim.CreateCompositeKey(objectType, attributes)
+}
+
+// SplitCompositeKey splits a composite key into its object type and attributes.
+func (s *MemStub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	if !isCompositeKey(compositeKey) {
+		return "", nil, fmt.Errorf("key %q is not a composite key", compositeKey)
+	}
+	components := []string{}
+	componentIndex := 1
+	for i := 1; i < len(compositeKey); i++ {
+		if compositeKey[i] == minUnicodeRuneValue {
+			components = append(components, compositeKey[componentIndex:i])
+			componentIndex = i + 1
+		}
+	}
+	return components[0], components[1:], nil
+}
+
+// GetQueryResult evaluates a CouchDB Mango query against the committed world state and returns an iterator over
+// the matching entries. Selectors, sort, skip, limit and fields are honoured; only values that are JSON objects
+// are queryable, as on a peer backed by CouchDB.
+func (s *MemStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	q, err := parseMangoQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	results, err := q.execute(s.state.rangeKVs("", ""))
+	if err != nil {
+		return nil, err
+	}
+	return newStateIterator(results), nil
+}
+
+// GetQueryResultWithPagination is GetQueryResult returning at most `pageSize` results, starting at `bookmark` if
+// it is set. The bookmark of the next page is the key of the next result, or empty once every result has been
+// returned.
+func (s *MemStub) GetQueryResultWithPagination(query string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	q, err := parseMangoQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := q.execute(s.state.rangeKVs("", ""))
+	if err != nil {
+		return nil, nil, err
+	}
+	if bookmark != "" {
+		start := -1
+		for i, kv := range results {
+			if kv.Key == bookmark {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return nil, nil, fmt.Errorf("bookmark %q is not part of the query result", bookmark)
+		}
+		results = results[start:]
+	}
+	iterator, metadata := paginate(results, pageSize)
+	return iterator, metadata, nil
+}
+
+// GetHistoryForKey returns an iterator over the committed modifications of `key`, from newest to oldest.
+func (s *MemStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	modifications := s.history[key]
+	results := make([]*queryresult.KeyModification, 0, len(modifications))
+	for i := len(modifications) - 1; i >= 0; i-- {
+		results = append(results, modifications[i])
+	}
+	return &historyIterator{results: results}, nil
+}
+
+// GetPrivateData returns the committed value of `key` in the private data collection. Writes of the current
+// transaction are not visible. If the key does not exist, (nil, nil) is returned.
+func (s *MemStub) GetPrivateData(collection, key string) ([]byte, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	return s.collection(collection).state.get(key), nil
+}
+
+// GetPrivateDataHash returns the SHA-256 hash of the committed value of `key` in the private data collection, or
+// nil if the key does not exist.
+func (s *MemStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, err := s.GetPrivateData(collection, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
+// PutPrivateData records `key` and `value` in the write set of the private data collection.
+func (s *MemStub) PutPrivateData(collection string, key string, value []byte) error {
+	if err := validateCollection(collection); err != nil {
+		return err
+	}
+	if key == "" {
+		return fmt.Errorf("key must not be an empty string")
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	s.collection(collection).writes[key] = KVWrite{Key: key, Value: append([]byte(nil), value...)}
+	return nil
+}
+
+// DelPrivateData records the deletion of `key` in the write set of the private data collection.
+func (s *MemStub) DelPrivateData(collection, key string) error {
+	if err := validateCollection(collection); err != nil {
+		return err
+	}
+	s.collection(collection).writes[key] = KVWrite{Key: key, IsDelete: true}
+	return nil
+}
+
+// PurgePrivateData records the deletion of `key` in the write set of the private data collection. MemStub keeps
+// no private data history, so purging behaves like DelPrivateData.
+func (s *MemStub) PurgePrivateData(collection, key string) error {
+	return s.DelPrivateData(collection, key)
+}
+
+// SetPrivateDataValidationParameter sets the key-level endorsement policy of `key` in the private data collection.
+func (s *MemStub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	if err := validateCollection(collection); err != nil {
+		return err
+	}
+	s.collection(collection).validationParams[key] = ep
+	return nil
+}
+
+// GetPrivateDataValidationParameter returns the key-level endorsement policy of `key` in the private data
+// collection, or nil if none is set.
+func (s *MemStub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	return s.collection(collection).validationParams[key], nil
+}
+
+// GetPrivateDataByRange returns an iterator over the committed keys of the private data collection between
+// startKey (inclusive) and endKey (exclusive), in lexical order.
+func (s *MemStub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return newStateIterator(s.collection(collection).state.rangeKVs(startKey, endKey)), nil
+}
+
+// GetPrivateDataByPartialCompositeKey returns an iterator over the committed composite keys of the private data
+// collection whose prefix matches the given object type and attributes.
+func (s *MemStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	startKey, endKey, err := createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	return newStateIterator(s.collection(collection).state.rangeKVs(startKey, endKey)), nil
+}
+
+// GetPrivateDataQueryResult evaluates a CouchDB Mango query against the committed state of the private data
+// collection, like GetQueryResult.
+func (s *MemStub) GetPrivateDataQueryResult(collection, query string) (shim.StateQueryIteratorInterface, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	q, err := parseMangoQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	results, err := q.execute(s.collection(collection).state.rangeKVs("", ""))
+	if err != nil {
+		return nil, err
+	}
+	return newStateIterator(results), nil
+}
+
+// GetCreator returns the serialized identity set with SetCreator.
+func (s *MemStub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+// GetTransient returns the transient map of the current transaction.
+func (s *MemStub) GetTransient() (map[string][]byte, error) {
+	return s.transient, nil
+}
+
+// GetBinding is not supported, as the binding is computed by the peer from the proposal.
+func (s *MemStub) GetBinding() ([]byte, error) {
+	return nil, notSupported("GetBinding")
+}
+
+// GetDecorations returns the decorations of the current transaction.
+func (s *MemStub) GetDecorations() map[string][]byte {
+	return s.decorations
+}
+
+// GetSignedProposal returns the signed proposal set with SetSignedProposal.
+func (s *MemStub) GetSignedProposal() (*pb.SignedProposal, error) {
+	return s.proposal, nil
+}
+
+// GetTxTimestamp returns the timestamp of the current transaction.
+func (s *MemStub) GetTxTimestamp() (*timestamppb.Timestamp, error) {
+	if s.txTimestamp == nil {
+		return nil, fmt.Errorf("no transaction has been started")
+	}
+	return s.txTimestamp, nil
+}
+
+// SetEvent sets the event of the current transaction, replacing any event set before.
+func (s *MemStub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return fmt.Errorf("event name can not be empty string")
+	}
+	s.event = &pb.ChaincodeEvent{TxId: s.txID, EventName: name, Payload: payload}
+	return nil
+}
+
+// collection returns the private data collection with the given name, creating it if needed.
+func (s *MemStub) collection(name string) *privateCollection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = newPrivateCollection()
+		s.collections[name] = c
+	}
+	return c
+}
+
+// validateCollection makes sure a private data collection is named.
+func validateCollection(collection string) error {
+	if collection == "" {
+		return fmt.Errorf("collection must not be an empty string")
+	}
+	return nil
+}
+
+// paginate returns an iterator over the first `pageSize` results and the metadata pointing to the next result.
+func paginate(results []*queryresult.KV, pageSize int32) (*stateIterator, *pb.QueryResponseMetadata) {
+	bookmark := ""
+	if pageSize > 0 && len(results) > int(pageSize) {
+		bookmark = results[pageSize].Key
+		results = results[:pageSize]
+	}
+	return newStateIterator(results), &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(results)), Bookmark: bookmark}
+}
+
+// chaincodeKey returns the key a chaincode is registered under.
+func chaincodeKey(name, channel string) string {
+	return name + "/" + channel
+}
+
+// notSupported returns the error reported by stub functions that have no in-memory implementation.
+func notSupported(method string) error {
+	return fmt.Errorf("%s is not supported by MemStub", method)
+}
+
+// isCompositeKey reports whether the key lives in the composite key namespace.
+func isCompositeKey(key string) bool {
+	return len(key) > 0 && key[0] == compositeKeyNamespace[0]
+}
+
+// validateSimpleKeys makes sure that simple keys do not reach into the composite key namespace.
+func validateSimpleKeys(simpleKeys ...string) error {
+	for _, key := range simpleKeys {
+		if isCompositeKey(key) {
+			return fmt.Errorf(`first character of the key [%s] contains a null character which is not allowed`, key)
+		}
+	}
+	return nil
+}
+
+// createRangeKeysForPartialCompositeKey returns the range covering every composite key that starts with the given
+// object type and attributes.
+func createRangeKeysForPartialCompositeKey(objectType string, attributes []string) (string, string, error) {
+	partialCompositeKey, err := shim.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return "", "", err
+	}
+	return partialCompositeKey, partialCompositeKey + string(maxUnicodeRuneValue), nil
+}
@@ -0,0 +1,410 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"crypto/sha256"
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+var _ shim.ChaincodeStubInterface = (*MemStub)(nil)
+
+func TestMemStubWriteSetSemantics(t *testing.T) {
+	stub := NewMemStub("kalp")
+	stub.StartTransaction("tx1")
+
+	// Check that uncommitted writes are not visible to GetState
+	t.Run("Check uncommitted writes are not visible", func(t *testing.T) {
+		require.NoError(t, stub.PutState("key", []byte("value")))
+
+		value, err := stub.GetState("key")
+		require.NoError(t, err)
+		require.Nil(t, value)
+		require.Equal(t, []KVWrite{{Key: "key", Value: []byte("value")}}, stub.WriteSet())
+	})
+
+	// Check that committed writes are visible to GetState
+	t.Run("Check committed writes are visible", func(t *testing.T) {
+		writeSet := stub.CommitTransaction()
+		require.Equal(t, []KVWrite{{Key: "key", Value: []byte("value")}}, writeSet)
+		require.Empty(t, stub.WriteSet())
+
+		value, err := stub.GetState("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	// Check that aborted writes are discarded
+	t.Run("Check aborted writes are discarded", func(t *testing.T) {
+		stub.StartTransaction("tx2")
+		require.NoError(t, stub.DelState("key"))
+		stub.AbortTransaction()
+
+		value, err := stub.GetState("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+	})
+
+	// Check that committed deletes remove the key
+	t.Run("Check committed deletes remove the key", func(t *testing.T) {
+		stub.StartTransaction("tx3")
+		require.NoError(t, stub.DelState("key"))
+		require.Equal(t, []KVWrite{{Key: "key", IsDelete: true}}, stub.CommitTransaction())
+
+		value, err := stub.GetState("key")
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+
+	// Check for failure response
+	t.Run("Check for empty key", func(t *testing.T) {
+		require.EqualError(t, stub.PutState("", []byte("value")), "key must not be an empty string")
+	})
+}
+
+func TestMemStubNotSupported(t *testing.T) {
+	stub := NewMemStub("kalp")
+
+	// Check that functions without an in-memory implementation fail instead of returning empty values
+	_, err := stub.GetBinding()
+	require.EqualError(t, err, "GetBinding is not supported by MemStub")
+}
+
+func TestMemStubGetStateByRange(t *testing.T) {
+	stub := NewMemStub("kalp")
+	for _, key := range []string{"key3", "key1", "key2", "other"} {
+		stub.SetCommittedState(key, []byte(key))
+	}
+	compositeKey, err := stub.CreateCompositeKey("owner", []string{"alice"})
+	require.NoError(t, err)
+	stub.SetCommittedState(compositeKey, []byte("alice"))
+
+	// Check for success response
+	t.Run("Check for bounded range", func(t *testing.T) {
+		iterator, err := stub.GetStateByRange("key1", "key3")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1", "key2"}, collectKeys(t, iterator))
+	})
+
+	t.Run("Check for unbounded range skips composite keys", func(t *testing.T) {
+		iterator, err := stub.GetStateByRange("", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1", "key2", "key3", "other"}, collectKeys(t, iterator))
+	})
+
+	// Check for failure response
+	t.Run("Check for composite start key", func(t *testing.T) {
+		_, err := stub.GetStateByRange(compositeKey, "")
+		require.Error(t, err)
+	})
+}
+
+func TestMemStubGetStateByPartialCompositeKey(t *testing.T) {
+	stub := NewMemStub("kalp")
+	stub.StartTransaction("tx1")
+	for _, attrs := range [][]string{{"alice", "asset2"}, {"bob", "asset3"}, {"alice", "asset1"}} {
+		key, err := stub.CreateCompositeKey("owner~asset", attrs)
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(key, []byte{0}))
+	}
+	stub.CommitTransaction()
+
+	iterator, err := stub.GetStateByPartialCompositeKey("owner~asset", []string{"alice"})
+	require.NoError(t, err)
+
+	assets := []string{}
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		require.NoError(t, err)
+
+		objectType, attrs, err := stub.SplitCompositeKey(kv.Key)
+		require.NoError(t, err)
+		require.Equal(t, "owner~asset", objectType)
+		assets = append(assets, attrs[1])
+	}
+	require.NoError(t, iterator.Close())
+	require.Equal(t, []string{"asset1", "asset2"}, assets)
+
+	_, _, err = stub.SplitCompositeKey("simplekey")
+	require.Error(t, err)
+}
+
+func TestMemStubGetHistoryForKey(t *testing.T) {
+	stub := NewMemStub("kalp")
+	for _, tx := range []struct{ id, value string }{{"tx1", "v1"}, {"tx2", "v2"}, {"tx3", ""}} {
+		stub.StartTransaction(tx.id)
+		if tx.value == "" {
+			require.NoError(t, stub.DelState("key"))
+		} else {
+			require.NoError(t, stub.PutState("key", []byte(tx.value)))
+		}
+		stub.CommitTransaction()
+	}
+
+	iterator, err := stub.GetHistoryForKey("key")
+	require.NoError(t, err)
+
+	txIDs := []string{}
+	for iterator.HasNext() {
+		km, err := iterator.Next()
+		require.NoError(t, err)
+		txIDs = append(txIDs, km.TxId)
+		if km.TxId == "tx3" {
+			require.True(t, km.IsDelete)
+		}
+	}
+	require.Equal(t, []string{"tx3", "tx2", "tx1"}, txIDs)
+
+	_, err = iterator.Next()
+	require.Error(t, err)
+}
+
+func TestMemStubSetEvent(t *testing.T) {
+	stub := NewMemStub("kalp")
+	stub.StartTransaction("tx1")
+
+	// Check for success response
+	require.NoError(t, stub.SetEvent("Transfer", []byte("payload")))
+	require.Equal(t, "Transfer", stub.Event().EventName)
+	require.Equal(t, []byte("payload"), stub.Event().Payload)
+	require.Equal(t, "tx1", stub.Event().TxId)
+
+	// Check for failure response
+	require.EqualError(t, stub.SetEvent("", nil), "event name can not be empty string")
+
+	// Check that the event is reset by a new transaction
+	stub.StartTransaction("tx2")
+	require.Nil(t, stub.Event())
+}
+
+func TestMemStubGetFunctionAndParameters(t *testing.T) {
+	stub := NewMemStub("kalp")
+
+	fn, params := stub.GetFunctionAndParameters()
+	require.Equal(t, "", fn)
+	require.Empty(t, params)
+
+	stub.SetFunctionAndParameters("CreateNIU", "arg1", "arg2")
+	fn, params = stub.GetFunctionAndParameters()
+	require.Equal(t, "CreateNIU", fn)
+	require.Equal(t, []string{"arg1", "arg2"}, params)
+}
+
+// collectKeys drains the iterator and returns the keys it produced.
+func collectKeys(t *testing.T, iterator shim.StateQueryIteratorInterface) []string {
+	t.Helper()
+	defer iterator.Close()
+
+	keys := []string{}
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		require.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	return keys
+}
+
+// putChaincode stores its two parameters as key and value.
+type putChaincode struct{}
+
+func (putChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (putChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	_, params := stub.GetFunctionAndParameters()
+	if err := stub.PutState(params[0], []byte(params[1])); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success([]byte(stub.GetTxID()))
+}
+
+func TestMemStubInvokeChaincode(t *testing.T) {
+	stub := NewMemStub("kalp")
+	local := stub.RegisterChaincode("store", "", putChaincode{})
+	remote := stub.RegisterChaincode("store", "other", putChaincode{})
+	stub.StartTransaction("tx1")
+
+	// Check that same channel writes follow the calling transaction
+	t.Run("Check same channel invocation", func(t *testing.T) {
+		response := stub.InvokeChaincode("store", [][]byte{[]byte("Put"), []byte("key"), []byte("value")}, "")
+		require.Equal(t, int32(shim.OK), response.Status)
+		require.Equal(t, "tx1", string(response.Payload))
+
+		stub.AbortTransaction()
+		value, _ := local.GetState("key")
+		require.Nil(t, value)
+
+		stub.StartTransaction("tx2")
+		stub.InvokeChaincode("store", [][]byte{[]byte("Put"), []byte("key"), []byte("value")}, "kalp")
+		stub.CommitTransaction()
+		value, _ = local.GetState("key")
+		require.Equal(t, "value", string(value))
+	})
+
+	// Check that other channel writes are discarded
+	t.Run("Check other channel invocation", func(t *testing.T) {
+		stub.StartTransaction("tx3")
+		response := stub.InvokeChaincode("store", [][]byte{[]byte("Put"), []byte("key"), []byte("value")}, "other")
+		require.Equal(t, int32(shim.OK), response.Status)
+		stub.CommitTransaction()
+		value, _ := remote.GetState("key")
+		require.Nil(t, value)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		response := stub.InvokeChaincode("missing", nil, "")
+		require.Equal(t, int32(shim.ERROR), response.Status)
+		require.Equal(t, "chaincode missing is not available on channel kalp", response.Message)
+	})
+}
+
+func TestMemStubPrivateData(t *testing.T) {
+	stub := NewMemStub("kalp")
+	stub.SetCommittedState("key1", []byte("public"))
+	stub.StartTransaction("tx1")
+
+	// Check that private writes are kept per collection until commit
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, stub.PutPrivateData("org1", "key1", []byte(`{"price":10}`)))
+		require.NoError(t, stub.PutPrivateData("org1", "key2", []byte(`{"price":20}`)))
+		require.Equal(t, []KVWrite{{Key: "key1", Value: []byte(`{"price":10}`)}, {Key: "key2", Value: []byte(`{"price":20}`)}}, stub.PrivateWriteSet("org1"))
+		require.Empty(t, stub.WriteSet())
+
+		value, err := stub.GetPrivateData("org1", "key1")
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		stub.CommitTransaction()
+		value, err = stub.GetPrivateData("org1", "key1")
+		require.NoError(t, err)
+		require.Equal(t, `{"price":10}`, string(value))
+		value, err = stub.GetPrivateData("org2", "key1")
+		require.NoError(t, err)
+		require.Nil(t, value)
+		value, err = stub.GetState("key1")
+		require.NoError(t, err)
+		require.Equal(t, "public", string(value))
+
+		hash, err := stub.GetPrivateDataHash("org1", "key1")
+		require.NoError(t, err)
+		expected := sha256.Sum256([]byte(`{"price":10}`))
+		require.Equal(t, expected[:], hash)
+	})
+
+	// Check the private data queries
+	t.Run("Check for queries", func(t *testing.T) {
+		iterator, err := stub.GetPrivateDataByRange("org1", "", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1", "key2"}, collectKeys(t, iterator))
+
+		iterator, err = stub.GetPrivateDataQueryResult("org1", `{"selector":{"price":{"$gt":15}}}`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key2"}, collectKeys(t, iterator))
+	})
+
+	// Check that deletes and purges are discarded on abort and applied on commit
+	t.Run("Check for delete and purge", func(t *testing.T) {
+		stub.StartTransaction("tx2")
+		require.NoError(t, stub.DelPrivateData("org1", "key1"))
+		stub.AbortTransaction()
+		value, err := stub.GetPrivateData("org1", "key1")
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		stub.StartTransaction("tx3")
+		require.NoError(t, stub.DelPrivateData("org1", "key1"))
+		require.NoError(t, stub.PurgePrivateData("org1", "key2"))
+		stub.CommitTransaction()
+		iterator, err := stub.GetPrivateDataByRange("org1", "", "")
+		require.NoError(t, err)
+		require.Empty(t, collectKeys(t, iterator))
+	})
+
+	// Check that endorsement policies are kept per collection
+	t.Run("Check for validation parameters", func(t *testing.T) {
+		require.NoError(t, stub.SetPrivateDataValidationParameter("org1", "key1", []byte("policy")))
+		ep, err := stub.GetPrivateDataValidationParameter("org1", "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("policy"), ep)
+		ep, err = stub.GetPrivateDataValidationParameter("org2", "key1")
+		require.NoError(t, err)
+		require.Nil(t, ep)
+	})
+
+	// Check for failure response
+	t.Run("Check for empty collection", func(t *testing.T) {
+		require.EqualError(t, stub.PutPrivateData("", "key1", []byte("value")), "collection must not be an empty string")
+		_, err := stub.GetPrivateData("", "key1")
+		require.EqualError(t, err, "collection must not be an empty string")
+	})
+}
+
+func TestMemStubPagination(t *testing.T) {
+	stub := NewMemStub("kalp")
+	for _, key := range []string{"key1", "key2", "key3", "key4", "key5"} {
+		stub.SetCommittedState(key, []byte(`{"name":"`+key+`"}`))
+	}
+	for _, attrs := range [][]string{{"alice", "asset1"}, {"alice", "asset2"}, {"alice", "asset3"}, {"bob", "asset4"}} {
+		key, err := stub.CreateCompositeKey("owner~asset", attrs)
+		require.NoError(t, err)
+		stub.SetCommittedState(key, []byte{0})
+	}
+
+	// Check that the bookmarks walk the range
+	t.Run("Check for range pagination", func(t *testing.T) {
+		iterator, metadata, err := stub.GetStateByRangeWithPagination("key1", "key5", 3, "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1", "key2", "key3"}, collectKeys(t, iterator))
+		require.Equal(t, int32(3), metadata.FetchedRecordsCount)
+		require.Equal(t, "key4", metadata.Bookmark)
+
+		iterator, metadata, err = stub.GetStateByRangeWithPagination("key1", "key5", 3, metadata.Bookmark)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key4"}, collectKeys(t, iterator))
+		require.Empty(t, metadata.Bookmark)
+	})
+
+	t.Run("Check for partial composite key pagination", func(t *testing.T) {
+		iterator, metadata, err := stub.GetStateByPartialCompositeKeyWithPagination("owner~asset", []string{"alice"}, 2, "")
+		require.NoError(t, err)
+		require.Len(t, collectKeys(t, iterator), 2)
+		require.NotEmpty(t, metadata.Bookmark)
+
+		iterator, metadata, err = stub.GetStateByPartialCompositeKeyWithPagination("owner~asset", []string{"alice"}, 2, metadata.Bookmark)
+		require.NoError(t, err)
+		keys := collectKeys(t, iterator)
+		require.Len(t, keys, 1)
+		_, attrs, err := stub.SplitCompositeKey(keys[0])
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice", "asset3"}, attrs)
+		require.Empty(t, metadata.Bookmark)
+	})
+
+	t.Run("Check for query pagination", func(t *testing.T) {
+		query := `{"selector":{"name":{"$gt":"key1"}},"sort":[{"name":"desc"}]}`
+		iterator, metadata, err := stub.GetQueryResultWithPagination(query, 2, "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key5", "key4"}, collectKeys(t, iterator))
+		require.Equal(t, "key3", metadata.Bookmark)
+
+		iterator, metadata, err = stub.GetQueryResultWithPagination(query, 0, metadata.Bookmark)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key3", "key2"}, collectKeys(t, iterator))
+		require.Equal(t, int32(2), metadata.FetchedRecordsCount)
+		require.Empty(t, metadata.Bookmark)
+	})
+
+	// Check for failure response
+	t.Run("Check for invalid bookmark", func(t *testing.T) {
+		_, _, err := stub.GetQueryResultWithPagination(`{"selector":{}}`, 2, "missing")
+		require.EqualError(t, err, `bookmark "missing" is not part of the query result`)
+		_, _, err = stub.GetStateByPartialCompositeKeyWithPagination("owner~asset", []string{"alice"}, 2, "key1")
+		require.EqualError(t, err, `bookmark "key1" does not match the partial composite key`)
+	})
+}
@@ -0,0 +1,104 @@
+package kalptest
+
+import (
+	//Standard Libs
+	"sort"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+)
+
+// sortedState is a key/value map that keeps its keys in lexical order, the order in which the peer returns the
+// results of range queries.
+type sortedState struct {
+	keys   []string
+	values map[string][]byte
+}
+
+// newSortedState creates an empty sortedState.
+func newSortedState() *sortedState {
+	return &sortedState{values: make(map[string][]byte)}
+}
+
+// get returns the value stored under `key`, or nil if the key does not exist.
+func (st *sortedState) get(key string) []byte {
+	value, ok := st.values[key]
+	if !ok {
+		return nil
+	}
+	return append([]byte(nil), value...)
+}
+
+// put stores a copy of `value` under `key`.
+func (st *sortedState) put(key string, value []byte) {
+	if _, ok := st.values[key]; !ok {
+		i := sort.SearchStrings(st.keys, key)
+		st.keys = append(st.keys, "")
+		copy(st.keys[i+1:], st.keys[i:])
+		st.keys[i] = key
+	}
+	st.values[key] = append([]byte{}, value...)
+}
+
+// delete removes `key`. Deleting a missing key is a no-op.
+func (st *sortedState) delete(key string) {
+	if _, ok := st.values[key]; !ok {
+		return
+	}
+	i := sort.SearchStrings(st.keys, key)
+	st.keys = append(st.keys[:i], st.keys[i+1:]...)
+	delete(st.values, key)
+}
+
+// rangeKVs returns the entries between startKey (inclusive) and endKey (exclusive) in lexical order.
+// An empty endKey denotes an unbounded range.
+func (st *sortedState) rangeKVs(startKey, endKey string) []*queryresult.KV {
+	kvs := []*queryresult.KV{}
+	for i := sort.SearchStrings(st.keys, startKey); i < len(st.keys); i++ {
+		key := st.keys[i]
+		if endKey != "" && key >= endKey {
+			break
+		}
+		kvs = append(kvs, &queryresult.KV{Key: key, Value: st.get(key)})
+	}
+	return kvs
+}
+
+// privateCollection is the committed state, pending write set and key-level endorsement policies of a private
+// data collection.
+type privateCollection struct {
+	state            *sortedState
+	writes           map[string]KVWrite
+	validationParams map[string][]byte
+}
+
+// newPrivateCollection creates an empty privateCollection.
+func newPrivateCollection() *privateCollection {
+	return &privateCollection{
+		state:            newSortedState(),
+		writes:           make(map[string]KVWrite),
+		validationParams: make(map[string][]byte),
+	}
+}
+
+// commit applies the pending writes to the committed state of the collection.
+func (c *privateCollection) commit() {
+	for _, w := range c.writes {
+		if w.IsDelete {
+			c.state.delete(w.Key)
+		} else {
+			c.state.put(w.Key, w.Value)
+		}
+	}
+	c.writes = make(map[string]KVWrite)
+}
+
+// sortedWrites returns the writes of a write set sorted by key.
+func sortedWrites(writes map[string]KVWrite) []KVWrite {
+	writeSet := make([]KVWrite, 0, len(writes))
+	for _, w := range writes {
+		writeSet = append(writeSet, w)
+	}
+	sort.Slice(writeSet, func(i, j int) bool { return writeSet[i].Key < writeSet[j].Key })
+	return writeSet
+}
@@ -0,0 +1,225 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+const (
+	// DefaultKYCChaincodeName is the name of the chaincode queried for KYC when no other name is configured.
+	DefaultKYCChaincodeName = "kyc"
+	// DefaultKYCExistsFunction is the function checking whether a user has completed KYC.
+	DefaultKYCExistsFunction = "KycExists"
+	// DefaultKYCCreateFunction is the function creating the KYC record of a user.
+	DefaultKYCCreateFunction = "CreateKyc"
+
+	// KYCConfigInitFunction is the chaincode init function that stores a KYCConfig on the ledger. It takes the
+	// JSON encoded KYCConfig as its only argument.
+	KYCConfigInitFunction = "InitKYCConfig"
+
+	// Environment variables overriding the default KYC configuration.
+	EnvKYCChaincodeName       = "KALP_KYC_CHAINCODE"
+	EnvKYCChannelName         = "KALP_KYC_CHANNEL"
+	EnvKYCExistsFunction      = "KALP_KYC_EXISTS_FUNCTION"
+	EnvKYCCreateFunction      = "KALP_KYC_CREATE_FUNCTION"
+	EnvKYCExistsResponseField = "KALP_KYC_EXISTS_RESPONSE_FIELD"
+
+	kycConfigObjectType = "KYC-CONFIG"
+)
+
+// KYCConfig controls how KYC is checked and recorded: which chaincode is invoked on which channel, the functions
+// called on it and how the response of the exists function is parsed. Empty fields fall back to the next source
+// of configuration, in this order: the KYCConfig set on the Contract, the KYCConfig stored by the
+// KYCConfigInitFunction init function, the environment variables and finally the defaults.
+type KYCConfig struct {
+	ChaincodeName       string `json:"chaincodeName,omitempty"`       // Name of the KYC chaincode, "kyc" by default.
+	ChannelName         string `json:"channelName,omitempty"`         // Channel of the KYC chaincode, the current channel by default.
+	ExistsFunction      string `json:"existsFunction,omitempty"`      // Function checking KYC of a user, "KycExists" by default.
+	CreateFunction      string `json:"createFunction,omitempty"`      // Function creating a KYC record, "CreateKyc" by default.
+	ExistsResponseField string `json:"existsResponseField,omitempty"` // If set, the exists response is a JSON object and this field holds the result.
+
+	// ParseExistsResponse parses the payload of the exists function. If nil, the payload is parsed as a JSON object
+	// when ExistsResponseField is set, or with strconv.ParseBool otherwise.
+	ParseExistsResponse func(payload []byte) (bool, error) `json:"-"`
+}
+
+// DefaultKYCConfig returns the configuration used when nothing else is configured.
+//
+// Returns:
+//   - KYCConfig: The default KYC configuration.
+func DefaultKYCConfig() KYCConfig {
+	return KYCConfig{
+		ChaincodeName:  DefaultKYCChaincodeName,
+		ExistsFunction: DefaultKYCExistsFunction,
+		CreateFunction: DefaultKYCCreateFunction,
+	}
+}
+
+// KYCConfigFromEnv returns the default configuration overridden by the KALP_KYC_* environment variables.
+//
+// Returns:
+//   - KYCConfig: The KYC configuration read from the environment.
+func KYCConfigFromEnv() KYCConfig {
+	config := KYCConfig{
+		ChaincodeName:       os.Getenv(EnvKYCChaincodeName),
+		ChannelName:         os.Getenv(EnvKYCChannelName),
+		ExistsFunction:      os.Getenv(EnvKYCExistsFunction),
+		CreateFunction:      os.Getenv(EnvKYCCreateFunction),
+		ExistsResponseField: os.Getenv(EnvKYCExistsResponseField),
+	}
+	return config.merge(DefaultKYCConfig())
+}
+
+// WithDefaults returns the configuration with its empty fields filled from DefaultKYCConfig.
+//
+// Returns:
+//   - KYCConfig: The completed KYC configuration.
+func (c KYCConfig) WithDefaults() KYCConfig {
+	return c.merge(DefaultKYCConfig())
+}
+
+// merge fills the empty fields of the configuration from the fallback configuration.
+func (c KYCConfig) merge(fallback KYCConfig) KYCConfig {
+	if c.ChaincodeName == "" {
+		c.ChaincodeName = fallback.ChaincodeName
+	}
+	if c.ChannelName == "" {
+		c.ChannelName = fallback.ChannelName
+	}
+	if c.ExistsFunction == "" {
+		c.ExistsFunction = fallback.ExistsFunction
+	}
+	if c.CreateFunction == "" {
+		c.CreateFunction = fallback.CreateFunction
+	}
+	if c.ExistsResponseField == "" {
+		c.ExistsResponseField = fallback.ExistsResponseField
+	}
+	if c.ParseExistsResponse == nil {
+		c.ParseExistsResponse = fallback.ParseExistsResponse
+	}
+	return c
+}
+
+// parseExists converts the payload of the exists function into the KYC status of the user.
+func (c KYCConfig) parseExists(payload []byte) (bool, error) {
+	if c.ParseExistsResponse != nil {
+		return c.ParseExistsResponse(payload)
+	}
+	if c.ExistsResponseField == "" {
+		return strconv.ParseBool(string(payload))
+	}
+
+	response := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return false, fmt.Errorf("failed to parse kyc response: %v", err)
+	}
+	switch value := response[c.ExistsResponseField].(type) {
+	case bool:
+		return value, nil
+	case string:
+		return strconv.ParseBool(value)
+	}
+	return false, fmt.Errorf("kyc response does not have a boolean field %s", c.ExistsResponseField)
+}
+
+// SetKYCConfig sets the KYC configuration of the transaction. Its empty fields fall back to the configuration
+// stored on the ledger, the environment and the defaults. Contracts with a KYCConfig set it before each transaction.
+func (ctx *TransactionContext) SetKYCConfig(config KYCConfig) {
+	ctx.kycConfig = &config
+	ctx.kycCache = nil
+	ctx.kycConfigCache = nil
+}
+
+// GetKYCConfig returns the KYC configuration in effect for the transaction, resolved from the configuration set
+// with SetKYCConfig, the configuration stored by the KYCConfigInitFunction init function, the KALP_KYC_*
+// environment variables and the defaults, in that order. The configuration is resolved once per transaction, so
+// that the stored configuration is read at most once.
+//
+// Returns:
+//   - KYCConfig: The resolved KYC configuration.
+//   - error: An error if the configuration stored on the ledger could not be read.
+func (ctx *TransactionContext) GetKYCConfig() (KYCConfig, error) {
+	txID := ctx.GetStub().GetTxID()
+	if ctx.kycConfigCache != nil && ctx.kycConfigTxID == txID {
+		return *ctx.kycConfigCache, nil
+	}
+
+	config := KYCConfig{}
+	if ctx.kycConfig != nil {
+		config = *ctx.kycConfig
+	}
+
+	stored, err := getStoredKYCConfig(ctx.GetStub())
+	if err != nil {
+		return KYCConfig{}, err
+	}
+	config = config.merge(stored).merge(KYCConfigFromEnv())
+	ctx.kycConfigCache = &config
+	ctx.kycConfigTxID = txID
+	return config, nil
+}
+
+// getKYCChannel returns the channel of the KYC chaincode, which is the current channel unless configured otherwise.
+func (ctx *TransactionContext) getKYCChannel(config KYCConfig) (string, error) {
+	if config.ChannelName != "" {
+		return config.ChannelName, nil
+	}
+	return ctx.GetChannelName()
+}
+
+// kycConfigKey returns the key the KYC configuration is stored under. It lives in the composite key namespace so
+// that it never shows up in range queries of the contract.
+func kycConfigKey() (string, error) {
+	return shim.CreateCompositeKey(kycConfigObjectType, []string{})
+}
+
+// getStoredKYCConfig reads the KYC configuration stored by the KYCConfigInitFunction init function.
+func getStoredKYCConfig(stub shim.ChaincodeStubInterface) (KYCConfig, error) {
+	var config KYCConfig
+
+	key, err := kycConfigKey()
+	if err != nil {
+		return config, err
+	}
+	configJSON, err := stub.GetState(key)
+	if err != nil {
+		return config, fmt.Errorf("failed to read kyc config: %v", err)
+	}
+	if configJSON == nil {
+		return config, nil
+	}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return config, fmt.Errorf("failed to unmarshal kyc config: %v", err)
+	}
+	return config, nil
+}
+
+// putStoredKYCConfig validates the JSON encoded KYC configuration passed to the KYCConfigInitFunction init function
+// and stores it on the ledger.
+func putStoredKYCConfig(stub shim.ChaincodeStubInterface, params []string) error {
+	if len(params) != 1 {
+		return fmt.Errorf("incorrect number of arguments for %s. Expecting 1, got %d", KYCConfigInitFunction, len(params))
+	}
+
+	var config KYCConfig
+	if err := json.Unmarshal([]byte(params[0]), &config); err != nil {
+		return fmt.Errorf("failed to unmarshal kyc config: %v", err)
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal kyc config: %v", err)
+	}
+
+	key, err := kycConfigKey()
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, configJSON)
+}
@@ -0,0 +1,187 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const testKYCConfigKey = "\x00KYC-CONFIG\x00"
+
+func TestKYCConfigFromEnv(t *testing.T) {
+	// Check the defaults
+	t.Run("Check for default config", func(t *testing.T) {
+		require.Equal(t, DefaultKYCConfig(), KYCConfigFromEnv())
+	})
+
+	// Check the environment overrides the defaults
+	t.Run("Check for environment config", func(t *testing.T) {
+		t.Setenv(EnvKYCChaincodeName, "universalkyc")
+		t.Setenv(EnvKYCChannelName, "kyc-channel")
+		t.Setenv(EnvKYCExistsResponseField, "exists")
+
+		require.Equal(t, KYCConfig{
+			ChaincodeName:       "universalkyc",
+			ChannelName:         "kyc-channel",
+			ExistsFunction:      DefaultKYCExistsFunction,
+			CreateFunction:      DefaultKYCCreateFunction,
+			ExistsResponseField: "exists",
+		}, KYCConfigFromEnv())
+	})
+}
+
+func TestKYCConfigParseExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   KYCConfig
+		payload  string
+		expected bool
+		err      bool
+	}{
+		{"Check plain true", KYCConfig{}, "true", true, false},
+		{"Check plain false", KYCConfig{}, "false", false, false},
+		{"Check malformed plain", KYCConfig{}, "maybe", false, true},
+		{"Check JSON field", KYCConfig{ExistsResponseField: "exists"}, `{"exists":true}`, true, false},
+		{"Check JSON string field", KYCConfig{ExistsResponseField: "exists"}, `{"exists":"false"}`, false, false},
+		{"Check missing JSON field", KYCConfig{ExistsResponseField: "exists"}, `{"found":true}`, false, true},
+		{"Check malformed JSON", KYCConfig{ExistsResponseField: "exists"}, `true`, false, true},
+		{"Check custom parser", KYCConfig{ParseExistsResponse: func(payload []byte) (bool, error) {
+			return string(payload) == "VERIFIED", nil
+		}}, "VERIFIED", true, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.config.parseExists([]byte(tc.payload))
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestGetKYCConfig(t *testing.T) {
+	t.Setenv(EnvKYCChaincodeName, "envkyc")
+	t.Setenv(EnvKYCExistsFunction, "EnvExists")
+
+	mockStub := new(mocks.ChaincodeStubInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+
+	// Check the precedence of the contract, stored, environment and default configs
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx1").Times(3)
+		mockStub.On("GetState", testKYCConfigKey).Return([]byte(`{"chaincodeName":"storedkyc","channelName":"kyc-channel"}`), nil).Once()
+		ctx.SetKYCConfig(KYCConfig{ChannelName: "contract-channel"})
+
+		config, err := ctx.GetKYCConfig()
+		require.NoError(t, err)
+		require.Equal(t, "storedkyc", config.ChaincodeName)
+		require.Equal(t, "contract-channel", config.ChannelName)
+		require.Equal(t, "EnvExists", config.ExistsFunction)
+		require.Equal(t, DefaultKYCCreateFunction, config.CreateFunction)
+
+		// The stored config is read once per transaction
+		config, err = ctx.GetKYCConfig()
+		require.NoError(t, err)
+		require.Equal(t, "storedkyc", config.ChaincodeName)
+
+		// Setting the contract config resolves the config again
+		mockStub.On("GetState", testKYCConfigKey).Return(nil, nil).Once()
+		ctx.SetKYCConfig(KYCConfig{ChaincodeName: "contractkyc"})
+		config, err = ctx.GetKYCConfig()
+		require.NoError(t, err)
+		require.Equal(t, "contractkyc", config.ChaincodeName)
+
+		// A new transaction reads the stored config again
+		mockStub.On("GetTxID").Return("tx2").Once()
+		mockStub.On("GetState", testKYCConfigKey).Return([]byte(`{"channelName":"new-channel"}`), nil).Once()
+		config, err = ctx.GetKYCConfig()
+		require.NoError(t, err)
+		require.Equal(t, "contractkyc", config.ChaincodeName)
+		require.Equal(t, "new-channel", config.ChannelName)
+		mockStub.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx3")
+		mockStub.On("GetState", testKYCConfigKey).Return(nil, fmt.Errorf("ledger unavailable")).Once()
+		_, err := ctx.GetKYCConfig()
+		require.EqualError(t, err, "failed to read kyc config: ledger unavailable")
+
+		// Failures are not cached
+		mockStub.On("GetState", testKYCConfigKey).Return([]byte("not json"), nil).Once()
+		_, err = ctx.GetKYCConfig()
+		require.Error(t, err)
+	})
+}
+
+func TestGetKYCWithConfig(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetState", testKYCConfigKey).Return(nil, nil).Once()
+	mockStub.On("GetTxID").Return("tx1")
+	ctx.SetKYCConfig(KYCConfig{
+		ChaincodeName:       "universalkyc",
+		ChannelName:         "kyc-channel",
+		ExistsFunction:      "IsVerified",
+		CreateFunction:      "Register",
+		ExistsResponseField: "verified",
+	})
+
+	// Check that GetKYC uses the configured chaincode, channel, function and response field
+	t.Run("Check GetKYC", func(t *testing.T) {
+		expectedResponse := peer.Response{Status: shim.OK, Payload: []byte(`{"verified":true}`)}
+		mockStub.On("InvokeChaincode", "universalkyc", [][]byte{[]byte("IsVerified"), []byte("TestUser")}, "kyc-channel").Return(expectedResponse).Once()
+
+		result, err := ctx.GetKYC("TestUser")
+		require.NoError(t, err)
+		require.True(t, result)
+	})
+
+	// Check that PutKYC uses the configured chaincode, channel and function
+	t.Run("Check PutKYC", func(t *testing.T) {
+		invokeArgs := [][]byte{[]byte("Register"), []byte("TestUser"), []byte("kyc1"), []byte("hash1")}
+		mockStub.On("InvokeChaincode", "universalkyc", invokeArgs, "kyc-channel").Return(peer.Response{Status: shim.OK}).Once()
+
+		require.NoError(t, ctx.PutKYC("TestUser", "kyc1", "hash1"))
+	})
+}
+
+func TestInitKYCConfig(t *testing.T) {
+	chaincode := &ContractChaincode{}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		stub := new(mocks.ChaincodeStubInterface)
+		stub.On("GetFunctionAndParameters").Return(KYCConfigInitFunction, []string{`{"chaincodeName":"universalkyc"}`})
+		stub.On("PutState", testKYCConfigKey, []byte(`{"chaincodeName":"universalkyc"}`)).Return(nil).Once()
+
+		response := chaincode.Init(stub)
+		require.Equal(t, shim.OK, int(response.Status))
+		stub.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		stub := new(mocks.ChaincodeStubInterface)
+		stub.On("GetFunctionAndParameters").Return(KYCConfigInitFunction, []string{})
+
+		response := chaincode.Init(stub)
+		require.Equal(t, shim.ERROR, int(response.Status))
+		require.Equal(t, "incorrect number of arguments for InitKYCConfig. Expecting 1, got 0", response.Message)
+	})
+}
@@ -0,0 +1,200 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"time"
+)
+
+// KYCLevel is the tier of KYC a user has completed. Higher levels include the checks of lower levels.
+type KYCLevel int
+
+const (
+	KYCLevelNone     KYCLevel = iota // The user has not completed KYC.
+	KYCLevelBasic                    // The user has completed basic KYC.
+	KYCLevelEnhanced                 // The user has completed enhanced due diligence.
+)
+
+// String returns the name of the KYC level.
+func (l KYCLevel) String() string {
+	switch l {
+	case KYCLevelNone:
+		return "none"
+	case KYCLevelBasic:
+		return "basic"
+	case KYCLevelEnhanced:
+		return "enhanced"
+	}
+	return fmt.Sprintf("level %d", int(l))
+}
+
+// KYCStatus is the KYC state of a user as reported by a KYCProvider.
+type KYCStatus struct {
+	Level     KYCLevel  `json:"level"`               // The KYC level the user has completed.
+	Country   string    `json:"country,omitempty"`   // The jurisdiction the KYC was completed in.
+	ExpiresAt time.Time `json:"expiresAt,omitempty"` // The time the KYC expires; the zero time means it never expires.
+	Revoked   bool      `json:"revoked,omitempty"`   // True if the KYC has been revoked.
+}
+
+// KYCProvider looks up the KYC status of users. Implementations must be deterministic, as every endorsing peer
+// runs them, and should only read the ledger through the transaction context.
+type KYCProvider interface {
+	// GetKYCStatus returns the KYC status of the user. A user without KYC is reported with KYCLevelNone and no error.
+	GetKYCStatus(ctx TransactionContextInterface, userID string) (KYCStatus, error)
+}
+
+// KYCProviderFunc adapts an ordinary function to the KYCProvider interface.
+type KYCProviderFunc func(ctx TransactionContextInterface, userID string) (KYCStatus, error)
+
+// GetKYCStatus calls f(ctx, userID).
+func (f KYCProviderFunc) GetKYCStatus(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+	return f(ctx, userID)
+}
+
+// ChaincodeKYCProvider is the default KYCProvider. It invokes the exists function of the KYC chaincode described
+// by the KYCConfig of the transaction and reports KYCLevelBasic for users that have completed KYC.
+type ChaincodeKYCProvider struct{}
+
+// GetKYCStatus invokes the exists function of the KYC chaincode for the user.
+//
+// Parameters:
+//   - ctx: The transaction context used to invoke the KYC chaincode.
+//   - userID: The ID of the user to check.
+//
+// Returns:
+//   - KYCStatus: KYCLevelBasic if the user has completed KYC, KYCLevelNone otherwise.
+//   - error: An error if the KYC chaincode could not be queried or its response could not be parsed.
+func (ChaincodeKYCProvider) GetKYCStatus(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+	// Resolve the KYC chaincode, channel and function for the cross-chaincode invocation.
+	config, err := ctx.GetKYCConfig()
+	if err != nil {
+		return KYCStatus{}, err
+	}
+
+	channelName := config.ChannelName
+	if channelName == "" {
+		channelName, err = ctx.GetChannelName()
+		if err != nil {
+			return KYCStatus{}, fmt.Errorf("failed to get channel name: %s", err.Error())
+		}
+	}
+
+	// Invoke the exists function on the KYC chaincode in the channel.
+	queryArgs := [][]byte{[]byte(config.ExistsFunction), []byte(userID)}
+	response := ctx.InvokeChaincode(config.ChaincodeName, queryArgs, channelName)
+
+	// Check if the response status is not 200 OK.
+	if response.Status != 200 {
+		return KYCStatus{}, fmt.Errorf("failed to query kyc chaincode for user %s. Got status %d and error message: %s", userID, response.Status, response.Payload)
+	}
+
+	// Convert the response payload to a boolean.
+	kycCheck, err := config.parseExists(response.Payload)
+	if err != nil {
+		return KYCStatus{}, err
+	}
+	if !kycCheck {
+		return KYCStatus{Level: KYCLevelNone}, nil
+	}
+	return KYCStatus{Level: KYCLevelBasic}, nil
+}
+
+// SetKYCProvider sets the KYCProvider used by the KYC functions of the transaction. Contracts with a KYCProvider
+// set it before each transaction.
+func (ctx *TransactionContext) SetKYCProvider(provider KYCProvider) {
+	ctx.kycProvider = provider
+	ctx.kycCache = nil
+}
+
+// GetKYCProvider returns the KYCProvider of the transaction, ChaincodeKYCProvider if none has been set.
+//
+// Returns:
+//   - KYCProvider: The KYC provider of the transaction.
+func (ctx *TransactionContext) GetKYCProvider() KYCProvider {
+	if ctx.kycProvider == nil {
+		return ChaincodeKYCProvider{}
+	}
+	return ctx.kycProvider
+}
+
+// GetKYCStatus returns the KYC status of a user as reported by the KYCProvider of the transaction.
+// The status is remembered for the rest of the transaction; failed lookups are not remembered.
+//
+// Parameters:
+//   - userId: The ID of the user.
+//
+// Returns:
+//   - KYCStatus: The KYC status of the user.
+//   - error: An error if the provider failed to look up the user.
+func (ctx *TransactionContext) GetKYCStatus(userId string) (KYCStatus, error) {
+	// Return the result of an earlier lookup in this transaction.
+	cache := ctx.getKYCCache()
+	if status, ok := cache[userId]; ok {
+		return status, nil
+	}
+
+	status, err := ctx.GetKYCProvider().GetKYCStatus(ctx, userId)
+	if err != nil {
+		return KYCStatus{}, err
+	}
+	cache[userId] = status
+	return status, nil
+}
+
+// checkKYCStatus returns an *AccessDeniedError unless the status is at least `minLevel`, not revoked and not expired at the
+// transaction timestamp.
+func (ctx *TransactionContext) checkKYCStatus(userId string, status KYCStatus, minLevel KYCLevel) error {
+	if status.Level == KYCLevelNone {
+		return accessDenied("user %s has not completed KYC", userId)
+	}
+	if status.Revoked {
+		return accessDenied("KYC of user %s has been revoked", userId)
+	}
+	expired, err := ctx.isKYCExpired(status)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return accessDenied("KYC of user %s expired at %s", userId, status.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if status.Level < minLevel {
+		return accessDenied("user %s has KYC level %s, but %s is required", userId, status.Level, minLevel)
+	}
+	return nil
+}
+
+// isKYCExpired reports whether the status has expired at the transaction timestamp, which is the same on every
+// endorser, unlike the local clock.
+func (ctx *TransactionContext) isKYCExpired(status KYCStatus) (bool, error) {
+	if status.ExpiresAt.IsZero() {
+		return false, nil
+	}
+	txTimestamp, err := ctx.GetTxTimestamp()
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+	return !txTimestamp.AsTime().Before(status.ExpiresAt), nil
+}
+
+// RequireKYCLevel checks that the user who submitted the transaction has an active KYC of at least `minLevel`.
+//
+// Parameters:
+//   - minLevel: The minimum KYC level required.
+//
+// Returns:
+//   - error: An *AccessDeniedError if the KYC is missing, revoked, expired or below `minLevel`, or an error if the
+//     KYC status could not be looked up.
+func (ctx *TransactionContext) RequireKYCLevel(minLevel KYCLevel) error {
+	// Get the user ID
+	userID, err := ctx.GetUserID()
+	if err != nil {
+		return err
+	}
+
+	// Look up the KYC status of the user.
+	status, err := ctx.GetKYCStatus(userID)
+	if err != nil {
+		return fmt.Errorf("failed to perform KYC check for user %s. Error: %v", userID, err)
+	}
+	return ctx.checkKYCStatus(userID, status, minLevel)
+}
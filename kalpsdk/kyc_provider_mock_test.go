@@ -0,0 +1,48 @@
+package kalpsdk_test
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/mocks"
+	"github.com/p2eengineering/kalp-sdk-public/response"
+)
+
+func TestChaincodeKYCProvider(t *testing.T) {
+	provider := kalpsdk.ChaincodeKYCProvider{}
+	existsArgs := [][]byte{[]byte(kalpsdk.DefaultKYCExistsFunction), []byte("TestUser")}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		ctx := mocks.NewTransactionContextInterface(t)
+		ctx.On("GetKYCConfig").Return(kalpsdk.DefaultKYCConfig(), nil).Once()
+		ctx.On("GetChannelName").Return("universalkyc", nil).Once()
+		ctx.On("InvokeChaincode", kalpsdk.DefaultKYCChaincodeName, existsArgs, "universalkyc").Return(response.Response{Response: peer.Response{Status: shim.OK, Payload: []byte("true")}}).Once()
+
+		status, err := provider.GetKYCStatus(ctx, "TestUser")
+		require.NoError(t, err)
+		require.Equal(t, kalpsdk.KYCLevelBasic, status.Level)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		ctx := mocks.NewTransactionContextInterface(t)
+		ctx.On("GetKYCConfig").Return(kalpsdk.KYCConfig{}, fmt.Errorf("failed to read kyc config: ledger unavailable")).Once()
+		_, err := provider.GetKYCStatus(ctx, "TestUser")
+		require.EqualError(t, err, "failed to read kyc config: ledger unavailable")
+
+		ctx = mocks.NewTransactionContextInterface(t)
+		ctx.On("GetKYCConfig").Return(kalpsdk.DefaultKYCConfig(), nil).Once()
+		ctx.On("GetChannelName").Return("", fmt.Errorf("no channel")).Once()
+		_, err = provider.GetKYCStatus(ctx, "TestUser")
+		require.EqualError(t, err, "failed to get channel name: no channel")
+	})
+}
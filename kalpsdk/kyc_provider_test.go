@@ -0,0 +1,145 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+	"time"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// base64 of "x509::CN=TestOwner,OU=client::CN=ca.org1.example.com"
+const testOwnerID = "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ=="
+
+func TestKYCLevelString(t *testing.T) {
+	require.Equal(t, "none", KYCLevelNone.String())
+	require.Equal(t, "basic", KYCLevelBasic.String())
+	require.Equal(t, "enhanced", KYCLevelEnhanced.String())
+	require.Equal(t, "level 7", KYCLevel(7).String())
+}
+
+func TestGetKYCStatus(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetTxID").Return("tx1")
+
+	calls := 0
+	ctx.SetKYCProvider(KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+		calls++
+		if userID == "broken" {
+			return KYCStatus{}, fmt.Errorf("provider unavailable")
+		}
+		return KYCStatus{Level: KYCLevelEnhanced, Country: "IN"}, nil
+	}))
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			status, err := ctx.GetKYCStatus("TestUser")
+			require.NoError(t, err)
+			require.Equal(t, KYCStatus{Level: KYCLevelEnhanced, Country: "IN"}, status)
+		}
+		require.Equal(t, 1, calls)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := ctx.GetKYCStatus("broken")
+		require.EqualError(t, err, "provider unavailable")
+
+		_, err = ctx.GetKYC("broken")
+		require.EqualError(t, err, "provider unavailable")
+	})
+}
+
+func TestGetKYCWithStatus(t *testing.T) {
+	txTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   KYCStatus
+		expected bool
+	}{
+		{"Check none", KYCStatus{Level: KYCLevelNone}, false},
+		{"Check basic", KYCStatus{Level: KYCLevelBasic}, true},
+		{"Check revoked", KYCStatus{Level: KYCLevelEnhanced, Revoked: true}, false},
+		{"Check not expired", KYCStatus{Level: KYCLevelBasic, ExpiresAt: txTime.Add(time.Hour)}, true},
+		{"Check expired", KYCStatus{Level: KYCLevelBasic, ExpiresAt: txTime}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStub := new(mocks.ChaincodeStubInterface)
+			ctx := &TransactionContext{
+				stub: mockStub,
+			}
+			mockStub.On("GetTxID").Return("tx1")
+			mockStub.On("GetTxTimestamp").Return(timestamppb.New(txTime), nil)
+			ctx.SetKYCProvider(KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+				return tc.status, nil
+			}))
+
+			result, err := ctx.GetKYC("TestUser")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPutStateWithKYCLevel(t *testing.T) {
+	txTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   KYCStatus
+		minLevel KYCLevel
+		err      string
+	}{
+		{"Check for success response", KYCStatus{Level: KYCLevelEnhanced}, KYCLevelEnhanced, ""},
+		{"Check for insufficient level", KYCStatus{Level: KYCLevelBasic}, KYCLevelEnhanced, "access denied: user TestOwner has KYC level basic, but enhanced is required"},
+		{"Check for missing KYC", KYCStatus{Level: KYCLevelNone}, KYCLevelBasic, "access denied: user TestOwner has not completed KYC"},
+		{"Check for revoked KYC", KYCStatus{Level: KYCLevelEnhanced, Revoked: true}, KYCLevelBasic, "access denied: KYC of user TestOwner has been revoked"},
+		{"Check for expired KYC", KYCStatus{Level: KYCLevelEnhanced, ExpiresAt: txTime.Add(-time.Hour)}, KYCLevelBasic, "access denied: KYC of user TestOwner expired at 2023-12-31T23:00:00Z"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStub := new(mocks.ChaincodeStubInterface)
+			mockClientIdentity := new(mocks.ClientIdentity)
+			ctx := &TransactionContext{
+				stub:           mockStub,
+				clientIdentity: mockClientIdentity,
+			}
+			mockStub.On("GetTxID").Return("tx1")
+			mockStub.On("GetTxTimestamp").Return(timestamppb.New(txTime), nil)
+			mockStub.On("PutState", "key", []byte("value")).Return(nil)
+			mockStub.On("DelState", "key").Return(nil)
+			mockClientIdentity.On("GetID").Return(testOwnerID, nil)
+			ctx.SetKYCProvider(KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+				require.Equal(t, "TestOwner", userID)
+				return tc.status, nil
+			}))
+
+			putErr := ctx.PutStateWithKYCLevel("key", []byte("value"), tc.minLevel)
+			delErr := ctx.DelStateWithKYCLevel("key", tc.minLevel)
+			if tc.err == "" {
+				require.NoError(t, putErr)
+				require.NoError(t, delErr)
+				mockStub.AssertCalled(t, "PutState", "key", []byte("value"))
+				mockStub.AssertCalled(t, "DelState", "key")
+				return
+			}
+			require.EqualError(t, putErr, tc.err)
+			require.EqualError(t, delErr, tc.err)
+			require.True(t, IsAccessDenied(putErr))
+			mockStub.AssertNotCalled(t, "PutState", "key", []byte("value"))
+			mockStub.AssertNotCalled(t, "DelState", "key")
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// nanosPerUnit is the number of nanos in a unit of currency.
+const nanosPerUnit = 1_000_000_000
+
+// nanosDigits is the number of decimal places of Money.Nanos.
+const nanosDigits = 9
+
+// defaultMinorUnits is the number of decimal places of the currencies that are not in the ISO 4217 table.
+const defaultMinorUnits = 2
+
+// MinorUnits returns the number of decimal places of a currency, e.g. 2 for INR, 0 for JPY and 3 for KWD, as given
+// by the ISO 4217 table. Currencies that are not in the table have two decimal places.
+//
+// Parameters:
+//   - currency: The ISO 4217 code of the currency.
+//
+// Returns:
+//   - int: The number of decimal places of the currency.
+func MinorUnits(currency string) int {
+	if c, ok := LookupCurrency(currency); ok {
+		return c.MinorUnits
+	}
+	return defaultMinorUnits
+}
+
+// Money is an exact amount of a currency, stored as whole units and billionths of a unit like google.type.Money,
+// so that amounts such as 0.10 INR are neither rounded when stored nor when added up.
+//
+// Units and Nanos must have the same sign, and Nanos must be between -999,999,999 and 999,999,999. An amount may
+// not be more precise than the minor units of its currency, e.g. 0.505 INR is invalid.
+type Money struct {
+	Units    int64  `json:"units"`    // The whole units of the amount.
+	Nanos    int32  `json:"nanos"`    // The billionths of a unit of the amount.
+	Currency string `json:"currency"` // The ISO 4217 code of the currency.
+}
+
+// NewMoney creates an amount from whole units and billionths of a unit.
+//
+// Parameters:
+//   - units: The whole units of the amount.
+//   - nanos: The billionths of a unit, with the same sign as units.
+//   - currency: The ISO 4217 code of the currency.
+//
+// Returns:
+//   - Money: The amount.
+//   - error: An error if the amount is invalid.
+func NewMoney(units int64, nanos int32, currency string) (Money, error) {
+	m := Money{Units: units, Nanos: nanos, Currency: currency}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// MoneyFromMinorUnits creates an amount from a number of minor units of the currency, e.g. 50 paise for 0.50 INR.
+//
+// Parameters:
+//   - minorUnits: The amount in minor units of the currency.
+//   - currency: The ISO 4217 code of the currency.
+//
+// Returns:
+//   - Money: The amount.
+//   - error: An error if the currency is empty.
+func MoneyFromMinorUnits(minorUnits int64, currency string) (Money, error) {
+	scale := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(nanosDigits-MinorUnits(currency))), nil)
+	return moneyFromNanos(big.NewInt(0).Mul(big.NewInt(minorUnits), scale), currency)
+}
+
+// ParseMoney parses a decimal amount, e.g. "0.50" or "-12.3", exactly. Exponents such as "1e2" are accepted so that
+// amounts encoded as JSON numbers can be parsed.
+//
+// Parameters:
+//   - amount: The decimal amount.
+//   - currency: The ISO 4217 code of the currency.
+//
+// Returns:
+//   - Money: The amount.
+//   - error: An error if the amount is not a decimal number, has more than nine decimal places, is out of range, or
+//     is more precise than the minor units of the currency.
+func ParseMoney(amount string, currency string) (Money, error) {
+	m, err := parseDecimal(amount, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// Validate checks that the amount is well formed: the currency is set, Units and Nanos have the same sign, Nanos is
+// in range and the amount is not more precise than the minor units of the currency.
+//
+// Returns:
+//   - error: An error describing the first problem found, or nil if the amount is valid.
+func (m Money) Validate() error {
+	if m.Currency == "" {
+		return fmt.Errorf("currency of the amount must not be empty")
+	}
+	if m.Nanos <= -nanosPerUnit || m.Nanos >= nanosPerUnit {
+		return fmt.Errorf("nanos %d of the amount must be between -999999999 and 999999999", m.Nanos)
+	}
+	if (m.Units > 0 && m.Nanos < 0) || (m.Units < 0 && m.Nanos > 0) {
+		return fmt.Errorf("units %d and nanos %d of the amount must have the same sign", m.Units, m.Nanos)
+	}
+	step := int32(math.Pow10(nanosDigits - MinorUnits(m.Currency)))
+	if m.Nanos%step != 0 {
+		return fmt.Errorf("amount %s is more precise than the %d decimal places of %s", m.decimal(), MinorUnits(m.Currency), m.Currency)
+	}
+	return nil
+}
+
+// Sign returns -1 if the amount is negative, 0 if it is zero and +1 if it is positive.
+func (m Money) Sign() int {
+	switch {
+	case m.Units > 0 || m.Nanos > 0:
+		return 1
+	case m.Units < 0 || m.Nanos < 0:
+		return -1
+	}
+	return 0
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Sign() > 0
+}
+
+// IsNegative reports whether the amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Sign() < 0
+}
+
+// Compare compares two amounts of the same currency.
+//
+// Parameters:
+//   - other: The amount to compare with.
+//
+// Returns:
+//   - int: -1 if m is less than other, 0 if they are equal and +1 if m is greater.
+//   - error: An error if the currencies differ.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	return m.nanos().Cmp(other.nanos()), nil
+}
+
+// Equal reports whether two amounts have the same value and currency.
+func (m Money) Equal(other Money) bool {
+	return m.Currency == other.Currency && m.Units == other.Units && m.Nanos == other.Nanos
+}
+
+// Add returns the sum of two amounts of the same currency.
+//
+// Parameters:
+//   - other: The amount to add.
+//
+// Returns:
+//   - Money: The sum.
+//   - error: An error if the currencies differ or the sum is out of range.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return moneyFromNanos(new(big.Int).Add(m.nanos(), other.nanos()), m.Currency)
+}
+
+// Sub returns the difference of two amounts of the same currency.
+//
+// Parameters:
+//   - other: The amount to subtract.
+//
+// Returns:
+//   - Money: The difference.
+//   - error: An error if the currencies differ or the difference is out of range.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return moneyFromNanos(new(big.Int).Sub(m.nanos(), other.nanos()), m.Currency)
+}
+
+// Mul returns the amount multiplied by a whole number, e.g. a unit price by a quantity.
+//
+// Parameters:
+//   - factor: The number to multiply by.
+//
+// Returns:
+//   - Money: The product.
+//   - error: An error if the product is out of range.
+func (m Money) Mul(factor int64) (Money, error) {
+	return moneyFromNanos(new(big.Int).Mul(m.nanos(), big.NewInt(factor)), m.Currency)
+}
+
+// Neg returns the amount with the opposite sign.
+//
+// Returns:
+//   - Money: The negated amount.
+//   - error: An error if the negated amount is out of range.
+func (m Money) Neg() (Money, error) {
+	return moneyFromNanos(new(big.Int).Neg(m.nanos()), m.Currency)
+}
+
+// MinorUnits returns the amount in minor units of its currency, e.g. 50 for 0.50 INR.
+//
+// Returns:
+//   - int64: The amount in minor units.
+//   - error: An error if the amount is more precise than the minor units, or out of range.
+func (m Money) MinorUnits() (int64, error) {
+	step := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(nanosDigits-MinorUnits(m.Currency))), nil)
+	minor, rem := new(big.Int).QuoRem(m.nanos(), step, new(big.Int))
+	if rem.Sign() != 0 {
+		return 0, fmt.Errorf("amount %s is more precise than the %d decimal places of %s", m.decimal(), MinorUnits(m.Currency), m.Currency)
+	}
+	if !minor.IsInt64() {
+		return 0, fmt.Errorf("amount %s %s is out of range", m.decimal(), m.Currency)
+	}
+	return minor.Int64(), nil
+}
+
+// Decimal returns the amount as a decimal number with at least the decimal places of the currency, e.g. "0.50".
+func (m Money) Decimal() string {
+	return m.decimal()
+}
+
+// String returns the amount and its currency, e.g. "0.50 INR".
+func (m Money) String() string {
+	return m.decimal() + " " + m.Currency
+}
+
+// UnmarshalJSON decodes an amount encoded as {"units":...,"nanos":...,"currency":...} and checks that Units, Nanos
+// and their signs are consistent. The precision is checked by Validate, once the currency is known.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	type plain Money
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	money := Money(decoded)
+	if money.Nanos <= -nanosPerUnit || money.Nanos >= nanosPerUnit || (money.Units > 0 && money.Nanos < 0) || (money.Units < 0 && money.Nanos > 0) {
+		return fmt.Errorf("invalid amount: units %d and nanos %d", money.Units, money.Nanos)
+	}
+	*m = money
+	return nil
+}
+
+// decimal formats the amount with the decimal places of the currency, or more if Nanos needs them.
+func (m Money) decimal() string {
+	units, nanos := m.Units, int64(m.Nanos)
+	sign := ""
+	if units < 0 || nanos < 0 {
+		sign = "-"
+	}
+	if units < 0 {
+		units = -units
+	}
+	if nanos < 0 {
+		nanos = -nanos
+	}
+
+	fraction := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+	if digits := MinorUnits(m.Currency); len(fraction) < digits {
+		fraction += strings.Repeat("0", digits-len(fraction))
+	}
+	if fraction == "" {
+		return fmt.Sprintf("%s%d", sign, uint64(units))
+	}
+	return fmt.Sprintf("%s%d.%s", sign, uint64(units), fraction)
+}
+
+// nanos returns the amount in billionths of a unit.
+func (m Money) nanos() *big.Int {
+	total := new(big.Int).Mul(big.NewInt(m.Units), big.NewInt(nanosPerUnit))
+	return total.Add(total, big.NewInt(int64(m.Nanos)))
+}
+
+// sameCurrency checks that two amounts have the same currency.
+func (m Money) sameCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", m.Currency, other.Currency)
+	}
+	return nil
+}
+
+// parseDecimal parses a decimal amount exactly, without checking it against the currency.
+func parseDecimal(amount string, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" || strings.Trim(amount, "0123456789.eE+-") != "" {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	rat, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	nanos := new(big.Rat).Mul(rat, new(big.Rat).SetInt64(nanosPerUnit))
+	if !nanos.IsInt() {
+		return Money{}, fmt.Errorf("amount %s has more than %d decimal places", amount, nanosDigits)
+	}
+	units, rem := new(big.Int).QuoRem(nanos.Num(), big.NewInt(nanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return Money{}, fmt.Errorf("amount %s is out of range", amount)
+	}
+	return Money{Units: units.Int64(), Nanos: int32(rem.Int64()), Currency: currency}, nil
+}
+
+// moneyFromNanos creates a valid amount from a number of billionths of a unit.
+func moneyFromNanos(total *big.Int, currency string) (Money, error) {
+	units, nanos := new(big.Int).QuoRem(total, big.NewInt(nanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return Money{}, fmt.Errorf("amount of %s is out of range", currency)
+	}
+	return NewMoney(units.Int64(), int32(nanos.Int64()), currency)
+}
@@ -0,0 +1,145 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"math"
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		expected Money
+		decimal  string
+	}{
+		{"0.50", "INR", Money{Units: 0, Nanos: 500000000, Currency: "INR"}, "0.50"},
+		{"12", "INR", Money{Units: 12, Currency: "INR"}, "12.00"},
+		{"-12.3", "USD", Money{Units: -12, Nanos: -300000000, Currency: "USD"}, "-12.30"},
+		{"-0.05", "USD", Money{Nanos: -50000000, Currency: "USD"}, "-0.05"},
+		{"1e2", "JPY", Money{Units: 100, Currency: "JPY"}, "100"},
+		{"1.234", "KWD", Money{Units: 1, Nanos: 234000000, Currency: "KWD"}, "1.234"},
+		{"9223372036854775807.99", "INR", Money{Units: math.MaxInt64, Nanos: 990000000, Currency: "INR"}, "9223372036854775807.99"},
+	}
+
+	// Check for success response
+	for _, tc := range tests {
+		t.Run("Check for success response "+tc.amount, func(t *testing.T) {
+			m, err := ParseMoney(tc.amount, tc.currency)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, m)
+			require.Equal(t, tc.decimal, m.Decimal())
+			require.Equal(t, tc.decimal+" "+tc.currency, m.String())
+		})
+	}
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		for amount, expected := range map[string]string{
+			"":                    `invalid amount ""`,
+			"abc":                 `invalid amount "abc"`,
+			"1/2":                 `invalid amount "1/2"`,
+			"0x10":                `invalid amount "0x10"`,
+			"0.505":               "amount 0.505 is more precise than the 2 decimal places of INR",
+			"0.0000000001":        "amount 0.0000000001 has more than 9 decimal places",
+			"9223372036854775808": "amount 9223372036854775808 is out of range",
+		} {
+			_, err := ParseMoney(amount, "INR")
+			require.EqualError(t, err, expected, amount)
+		}
+		_, err := ParseMoney("1", "")
+		require.EqualError(t, err, "currency of the amount must not be empty")
+	})
+}
+
+func TestMoneyValidate(t *testing.T) {
+	_, err := NewMoney(1, 500000000, "INR")
+	require.NoError(t, err)
+
+	_, err = NewMoney(1, -500000000, "INR")
+	require.EqualError(t, err, "units 1 and nanos -500000000 of the amount must have the same sign")
+
+	_, err = NewMoney(0, 1000000000, "INR")
+	require.EqualError(t, err, "nanos 1000000000 of the amount must be between -999999999 and 999999999")
+
+	_, err = NewMoney(100, 500000000, "JPY")
+	require.EqualError(t, err, "amount 100.5 is more precise than the 0 decimal places of JPY")
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	price, err := ParseMoney("0.10", "INR")
+	require.NoError(t, err)
+
+	// Check that repeated additions stay exact
+	t.Run("Check for success response", func(t *testing.T) {
+		total := Money{Currency: "INR"}
+		for i := 0; i < 10; i++ {
+			total, err = total.Add(price)
+			require.NoError(t, err)
+		}
+		require.Equal(t, Money{Units: 1, Currency: "INR"}, total)
+
+		product, err := price.Mul(3)
+		require.NoError(t, err)
+		require.Equal(t, "0.30", product.Decimal())
+
+		difference, err := price.Sub(product)
+		require.NoError(t, err)
+		require.Equal(t, "-0.20", difference.Decimal())
+		require.True(t, difference.IsNegative())
+		negated, err := difference.Neg()
+		require.NoError(t, err)
+		back, err := negated.Sub(price)
+		require.NoError(t, err)
+		require.Equal(t, price, back)
+
+		cmp, err := price.Compare(product)
+		require.NoError(t, err)
+		require.Equal(t, -1, cmp)
+		require.True(t, price.Equal(Money{Nanos: 100000000, Currency: "INR"}))
+		require.False(t, price.IsZero())
+
+		minor, err := product.MinorUnits()
+		require.NoError(t, err)
+		require.Equal(t, int64(30), minor)
+
+		fromMinor, err := MoneyFromMinorUnits(1234, "KWD")
+		require.NoError(t, err)
+		require.Equal(t, "1.234 KWD", fromMinor.String())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := price.Add(Money{Units: 1, Currency: "USD"})
+		require.EqualError(t, err, "currency mismatch: INR and USD")
+
+		_, err = price.Compare(Money{Units: 1, Currency: "USD"})
+		require.EqualError(t, err, "currency mismatch: INR and USD")
+
+		_, err = Money{Units: math.MaxInt64, Currency: "INR"}.Add(Money{Units: 1, Currency: "INR"})
+		require.EqualError(t, err, "amount of INR is out of range")
+
+		_, err = Money{Units: math.MinInt64, Currency: "INR"}.Neg()
+		require.EqualError(t, err, "amount of INR is out of range")
+
+		_, err = Money{Nanos: 5, Currency: "INR"}.MinorUnits()
+		require.EqualError(t, err, "amount 0.000000005 is more precise than the 2 decimal places of INR")
+	})
+}
+
+func TestMoneyJSON(t *testing.T) {
+	m := Money{Units: 12, Nanos: 340000000, Currency: "INR"}
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, `{"units":12,"nanos":340000000,"currency":"INR"}`, string(data))
+
+	var decoded Money
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, m, decoded)
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"units":1,"nanos":-5,"currency":"INR"}`), &decoded), "invalid amount: units 1 and nanos -5")
+}
@@ -0,0 +1,125 @@
+package kalpsdk
+
+import (
+	//Third party Libs
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// QueryResponseMetadata is the metadata returned by the paginated queries.
+type QueryResponseMetadata struct {
+	FetchedRecordsCount int32  `json:"fetchedRecordsCount"` // The number of records returned in the page.
+	Bookmark            string `json:"bookmark"`            // The bookmark to pass to fetch the next page.
+}
+
+// Page is one page of the results of a paginated query, ready to be returned to clients for cursor paging. Pass
+// Bookmark back to the query to fetch the next page.
+//
+// The contract API cannot describe generic types in the chaincode metadata, so contract functions must return a
+// type defined from an instantiated Page, e.g.:
+//
+//	type NIUPage kalpsdk.Page[NIU]
+type Page[T any] struct {
+	Records             []T    `json:"records"`             // The records of the page.
+	FetchedRecordsCount int32  `json:"fetchedRecordsCount"` // The number of records in the page.
+	Bookmark            string `json:"bookmark"`            // The bookmark of the next page.
+}
+
+// NewPage reads every record of a paginated query into a Page, decoding the values as JSON into T. The iterator
+// is closed when NewPage returns.
+//
+// Parameters:
+//   - iterator: The iterator returned by a paginated query.
+//   - metadata: The metadata returned by the paginated query.
+//
+// Returns:
+//   - *Page[T]: The page of records.
+//   - error: An error if the iterator fails or a value could not be decoded into T.
+func NewPage[T any](iterator StateQueryIteratorInterface, metadata *QueryResponseMetadata) (*Page[T], error) {
+	records, err := Collect[T](iterator)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{Records: records}
+	if metadata != nil {
+		page.FetchedRecordsCount = metadata.FetchedRecordsCount
+		page.Bookmark = metadata.Bookmark
+	}
+	return page, nil
+}
+
+// newQueryResponseMetadata converts the metadata returned by the stub.
+func newQueryResponseMetadata(metadata *pb.QueryResponseMetadata) *QueryResponseMetadata {
+	if metadata == nil {
+		return nil
+	}
+	return &QueryResponseMetadata{FetchedRecordsCount: metadata.FetchedRecordsCount, Bookmark: metadata.Bookmark}
+}
+
+// GetStateByRangeWithPagination returns a range iterator over a set of keys in the ledger, like GetStateByRange,
+// but returns at most `pageSize` keys, starting at `bookmark`. An empty bookmark starts at startKey. The bookmark
+// of the next page is returned in the metadata. Paginated queries are only allowed in read-only transactions.
+// Call Close() on the returned StateQueryIteratorInterface object when done.
+//
+// Parameters:
+//   - startKey: The first key of the range.
+//   - endKey: The key after the last key of the range.
+//   - pageSize: The maximum number of keys to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the keys of the page.
+//   - *QueryResponseMetadata: The number of keys fetched and the bookmark of the next page.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetStateByRangeWithPagination(startKey string, endKey string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error) {
+	iterator, metadata, err := ctx.GetStub().GetStateByRangeWithPagination(startKey, endKey, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterator, newQueryResponseMetadata(metadata), nil
+}
+
+// GetStateByPartialCompositeKeyWithPagination queries the state in the ledger based on a given partial composite
+// key, like GetStateByPartialCompositeKey, but returns at most `pageSize` keys, starting at `bookmark`. The bookmark
+// of the next page is returned in the metadata. Paginated queries are only allowed in read-only transactions.
+// Call Close() on the returned StateQueryIteratorInterface object when done.
+//
+// Parameters:
+//   - objectType: The object type of the composite keys.
+//   - keys: The leading attributes of the composite keys.
+//   - pageSize: The maximum number of keys to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the keys of the page.
+//   - *QueryResponseMetadata: The number of keys fetched and the bookmark of the next page.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error) {
+	iterator, metadata, err := ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(objectType, keys, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterator, newQueryResponseMetadata(metadata), nil
+}
+
+// GetQueryResultWithPagination performs a "rich" query against the state database, like GetQueryResult, but
+// returns at most `pageSize` records, starting at `bookmark`. The bookmark of the next page is returned in the
+// metadata. Paginated queries are only allowed in read-only transactions.
+// Call Close() on the returned StateQueryIteratorInterface object when done.
+//
+// Parameters:
+//   - query: The query in the syntax of the state database.
+//   - pageSize: The maximum number of records to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the records of the page.
+//   - *QueryResponseMetadata: The number of records fetched and the bookmark of the next page.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error) {
+	iterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(query, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return iterator, newQueryResponseMetadata(metadata), nil
+}
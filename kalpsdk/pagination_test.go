@@ -0,0 +1,88 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginatedQueries(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	mockState := new(mocks.StateQueryIteratorInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+	metadata := &pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "key3"}
+	expected := &QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "key3"}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetStateByRangeWithPagination", "key1", "key5", int32(2), "").Return(mockState, metadata, nil).Once()
+		mockStub.On("GetStateByPartialCompositeKeyWithPagination", "owner~asset", []string{"alice"}, int32(2), "").Return(mockState, metadata, nil).Once()
+		mockStub.On("GetQueryResultWithPagination", `{"selector":{}}`, int32(2), "").Return(mockState, metadata, nil).Once()
+
+		iterator, md, err := ctx.GetStateByRangeWithPagination("key1", "key5", 2, "")
+		require.NoError(t, err)
+		require.Equal(t, mockState, iterator)
+		require.Equal(t, expected, md)
+
+		iterator, md, err = ctx.GetStateByPartialCompositeKeyWithPagination("owner~asset", []string{"alice"}, 2, "")
+		require.NoError(t, err)
+		require.Equal(t, mockState, iterator)
+		require.Equal(t, expected, md)
+
+		iterator, md, err = ctx.GetQueryResultWithPagination(`{"selector":{}}`, 2, "")
+		require.NoError(t, err)
+		require.Equal(t, mockState, iterator)
+		require.Equal(t, expected, md)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetQueryResultWithPagination", `{"selector":{}}`, int32(2), "bad").Return(nil, nil, fmt.Errorf("invalid bookmark")).Once()
+
+		iterator, md, err := ctx.GetQueryResultWithPagination(`{"selector":{}}`, 2, "bad")
+		require.EqualError(t, err, "invalid bookmark")
+		require.Nil(t, iterator)
+		require.Nil(t, md)
+	})
+}
+
+func TestNewPage(t *testing.T) {
+	type asset struct {
+		ID string `json:"id"`
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockState := new(mocks.StateQueryIteratorInterface)
+		mockState.On("HasNext").Return(true).Twice()
+		mockState.On("HasNext").Return(false).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: "a1", Value: []byte(`{"id":"a1"}`)}, nil).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: "a2", Value: []byte(`{"id":"a2"}`)}, nil).Once()
+		mockState.On("Close").Return(nil).Once()
+
+		page, err := NewPage[asset](mockState, &QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "a3"})
+		require.NoError(t, err)
+		require.Equal(t, &Page[asset]{Records: []asset{{ID: "a1"}, {ID: "a2"}}, FetchedRecordsCount: 2, Bookmark: "a3"}, page)
+		mockState.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockState := new(mocks.StateQueryIteratorInterface)
+		mockState.On("HasNext").Return(true).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: "a1", Value: []byte(`not json`)}, nil).Once()
+		mockState.On("Close").Return(nil).Once()
+
+		_, err := NewPage[asset](mockState, nil)
+		require.ErrorContains(t, err, "failed to unmarshal value of key a1")
+		mockState.AssertExpectations(t)
+	})
+}
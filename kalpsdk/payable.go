@@ -0,0 +1,139 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"reflect"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"golang.org/x/exp/slices"
+)
+
+// PayableFunction configures a function that must be paid for. The payment is the last argument of the function.
+type PayableFunction struct {
+	Price    Money  // The amount the function costs; a zero Price accepts any amount.
+	Currency string // The currency the function must be paid in; empty accepts any currency, or the currency of Price.
+}
+
+// Validate checks that the price and currency are consistent.
+//
+// Returns:
+//   - error: An error describing why the configuration is invalid.
+func (f PayableFunction) Validate() error {
+	if f.Currency != "" {
+		if err := ValidateCurrency(f.Currency); err != nil {
+			return err
+		}
+	}
+	if f.Price == (Money{}) {
+		return nil
+	}
+	if err := ValidateCurrency(f.Price.Currency); err != nil {
+		return fmt.Errorf("price: %v", err)
+	}
+	if err := f.Price.Validate(); err != nil {
+		return fmt.Errorf("price: %v", err)
+	}
+	if !f.Price.IsPositive() {
+		return fmt.Errorf("price %s must be positive", f.Price)
+	}
+	if f.Currency != "" && f.Currency != f.Price.Currency {
+		return fmt.Errorf("price %s is not in the currency %s", f.Price, f.Currency)
+	}
+	return nil
+}
+
+// Check checks that a payment meets the price and currency of the function.
+//
+// Parameters:
+//   - function: The name of the function.
+//   - payment: The payment.
+//
+// Returns:
+//   - error: An error describing why the payment does not meet the configuration.
+func (f PayableFunction) Check(function string, payment PaymentTracker) error {
+	if err := f.Validate(); err != nil {
+		return fmt.Errorf("invalid payable function %s: %v", function, err)
+	}
+
+	amount := payment.PaymentMetaData.Amount
+	if f.Currency != "" && amount.Currency != f.Currency {
+		return fmt.Errorf("%s must be paid in %s, got %s", function, f.Currency, amount.Currency)
+	}
+	if f.Price != (Money{}) && !amount.Equal(f.Price) {
+		return fmt.Errorf("payment amount %s does not match the price %s of %s", amount, f.Price, function)
+	}
+	return nil
+}
+
+// GetEvaluateTransactions returns the functions that only read the ledger, as set in EvaluateTransactions. They are
+// tagged as "evaluate" in the contract metadata and never take a payment. Functions are not detected as evaluate
+// transactions on their own: a query of a payable contract must be listed to be free.
+//
+// Returns:
+//   - []string: The names of the evaluate functions.
+func (c *Contract) GetEvaluateTransactions() []string {
+	return c.EvaluateTransactions
+}
+
+// isPayable reports whether a function of the contract takes a payment as its last argument: either it is one of
+// the PayableFunctions, or the contract is payable and the function is not an evaluate transaction.
+//
+// Parameters:
+//   - function: The name of the function.
+//
+// Returns:
+//   - bool: true if the function must be paid for.
+func (c *Contract) isPayable(function string) bool {
+	if c.isEvaluateTransaction(function) {
+		return false
+	}
+	if _, ok := c.PayableFunctions[function]; ok {
+		return true
+	}
+	return c.IsPayableContract
+}
+
+// hasPayableFunctions reports whether any function of the contract may be payable.
+func (c *Contract) hasPayableFunctions() bool {
+	return c.IsPayableContract || len(c.PayableFunctions) > 0
+}
+
+// isEvaluateTransaction reports whether a function only reads the ledger.
+func (c *Contract) isEvaluateTransaction(function string) bool {
+	return slices.Contains(c.EvaluateTransactions, function) || slices.Contains(c.evaluateTransactions, function)
+}
+
+// kalpContract returns the Contract embedded in a contract, so that NewChaincode can prepare it.
+func (c *Contract) kalpContract() *Contract {
+	return c
+}
+
+// prepare reads the evaluate transactions of the contract embedding c, which may override GetEvaluateTransactions,
+// and checks that they exist, so that a misspelt query of a payable contract does not silently take a payment. It
+// also checks that the payable functions exist and are not evaluate transactions.
+func (c *Contract) prepare(contract contractapi.ContractInterface) error {
+	if evaluation, ok := contract.(contractapi.EvaluationContractInterface); ok {
+		c.evaluateTransactions = evaluation.GetEvaluateTransactions()
+	}
+
+	contractType := reflect.TypeOf(contract)
+	for _, function := range append(append([]string{}, c.EvaluateTransactions...), c.evaluateTransactions...) {
+		if _, ok := contractType.MethodByName(function); !ok {
+			return fmt.Errorf("evaluate transaction %s is not a function of %s", function, contractType)
+		}
+	}
+	for _, function := range sortedKeys(c.PayableFunctions) {
+		if _, ok := contractType.MethodByName(function); !ok {
+			return fmt.Errorf("payable function %s is not a function of %s", function, contractType)
+		}
+		if c.isEvaluateTransaction(function) {
+			return fmt.Errorf("payable function %s must not be an evaluate transaction", function)
+		}
+		if err := c.PayableFunctions[function].Validate(); err != nil {
+			return fmt.Errorf("invalid payable function %s: %v", function, err)
+		}
+	}
+	return nil
+}
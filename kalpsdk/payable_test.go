@@ -0,0 +1,112 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+// payableTestContract is a contract with a payable and an evaluate function.
+type payableTestContract struct {
+	Contract
+}
+
+func (c *payableTestContract) Buy(ctx TransactionContextInterface, payment string) error {
+	return nil
+}
+
+func (c *payableTestContract) Read(ctx TransactionContextInterface, id string) (string, error) {
+	return id, nil
+}
+
+// evaluateTestContract declares its evaluate functions by overriding GetEvaluateTransactions.
+type evaluateTestContract struct {
+	payableTestContract
+}
+
+func (c *evaluateTestContract) GetEvaluateTransactions() []string {
+	return []string{"Read"}
+}
+
+func TestIsPayable(t *testing.T) {
+	// Check that only the listed functions are payable
+	t.Run("Check for payable functions", func(t *testing.T) {
+		contract := &Contract{PayableFunctions: map[string]PayableFunction{"Buy": {}}}
+		require.True(t, contract.isPayable("Buy"))
+		require.False(t, contract.isPayable("Read"))
+	})
+
+	// Check that evaluate functions of a payable contract are exempt
+	t.Run("Check for payable contract", func(t *testing.T) {
+		contract := &Contract{IsPayableContract: true, EvaluateTransactions: []string{"Read"}}
+		require.True(t, contract.isPayable("Buy"))
+		require.False(t, contract.isPayable("Read"))
+		require.Equal(t, []string{"Read"}, contract.GetEvaluateTransactions())
+	})
+
+	// Check that evaluate functions declared by the embedding contract are exempt
+	t.Run("Check for overridden evaluate transactions", func(t *testing.T) {
+		contract := &evaluateTestContract{}
+		contract.IsPayableContract = true
+		require.NoError(t, contract.prepare(contract))
+		require.True(t, contract.isPayable("Buy"))
+		require.False(t, contract.isPayable("Read"))
+	})
+}
+
+func TestPreparePayableFunctions(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		contract := &payableTestContract{}
+		contract.PayableFunctions = map[string]PayableFunction{"Buy": {Price: Money{Units: 10, Currency: "INR"}, Currency: "INR"}}
+		contract.EvaluateTransactions = []string{"Read"}
+		require.NoError(t, contract.prepare(contract))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		contract := &payableTestContract{}
+		contract.PayableFunctions = map[string]PayableFunction{"Sell": {}}
+		require.EqualError(t, contract.prepare(contract), "payable function Sell is not a function of *kalpsdk.payableTestContract")
+
+		contract.PayableFunctions = map[string]PayableFunction{"Read": {}}
+		contract.EvaluateTransactions = []string{"Read"}
+		require.EqualError(t, contract.prepare(contract), "payable function Read must not be an evaluate transaction")
+
+		contract.PayableFunctions = map[string]PayableFunction{"Buy": {Currency: "INR", Price: Money{Units: 1, Currency: "USD"}}}
+		require.EqualError(t, contract.prepare(contract), "invalid payable function Buy: price 1.00 USD is not in the currency INR")
+
+		_, err := NewChaincode(contract)
+		require.EqualError(t, err, "failed to create chaincode: invalid payable function Buy: price 1.00 USD is not in the currency INR")
+
+		// A misspelt evaluate transaction would make the query payable
+		contract = &payableTestContract{}
+		contract.IsPayableContract = true
+		contract.EvaluateTransactions = []string{"Raed"}
+		require.EqualError(t, contract.prepare(contract), "evaluate transaction Raed is not a function of *kalpsdk.payableTestContract")
+
+		evaluate := &evaluateTestContract{}
+		evaluate.EvaluateTransactions = []string{"Read", "List"}
+		require.EqualError(t, evaluate.prepare(evaluate), "evaluate transaction List is not a function of *kalpsdk.evaluateTestContract")
+	})
+}
+
+func TestPayableFunctionCheck(t *testing.T) {
+	function := PayableFunction{Price: Money{Units: 10, Currency: "INR"}}
+
+	// Check for success response
+	require.NoError(t, function.Check("Buy", testPayment(t, "10.00", "INR", "")))
+	require.NoError(t, PayableFunction{Currency: "USD"}.Check("Buy", testPayment(t, "3.25", "USD", "")))
+
+	// Check for failure response
+	require.EqualError(t, function.Check("Buy", testPayment(t, "9.99", "INR", "")), "payment amount 9.99 INR does not match the price 10.00 INR of Buy")
+	require.EqualError(t, PayableFunction{Currency: "USD"}.Check("Buy", testPayment(t, "3", "INR", "")), "Buy must be paid in USD, got INR")
+	require.EqualError(t, PayableFunction{Currency: "usd"}.Check("Buy", testPayment(t, "3", "USD", "")), `invalid payable function Buy: currency code "usd" must be in upper case, USD`)
+	require.EqualError(t, PayableFunction{Price: Money{Units: -1, Currency: "INR"}}.Validate(), "price -1.00 INR must be positive")
+
+	contract := &Contract{PayableFunctions: map[string]PayableFunction{"Buy": function}}
+	require.EqualError(t, contract.validatePayment("Buy", testPayment(t, "5", "INR", "")), "payment amount 5.00 INR does not match the price 10.00 INR of Buy")
+	require.NoError(t, contract.validatePayment("Donate", testPayment(t, "5", "INR", "")))
+}
@@ -0,0 +1,143 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// paymentMetaDataJSON is the JSON encoding of PaymentMetaData. The amount is either a Money object or, in the
+// legacy format, a number or numeric string in the currency given by currencyCode.
+type paymentMetaDataJSON struct {
+	Amount                 json.RawMessage `json:"amount"`
+	CurrencyCode           string          `json:"currencyCode"`
+	ApplicationReferenceId string          `json:"applicationReferenceId"`
+	IsPaymentEngineUsed    bool            `json:"isPaymentEngineUsed,omitempty"`
+}
+
+// MarshalJSON encodes the amount as a Money object, with currencyCode repeating its currency for clients reading
+// the legacy format.
+func (p PaymentMetaData) MarshalJSON() ([]byte, error) {
+	amount, err := json.Marshal(p.Amount)
+	if err != nil {
+		return nil, err
+	}
+	currencyCode := p.CurrencyCode
+	if p.Amount.Currency != "" {
+		currencyCode = p.Amount.Currency
+	}
+	return json.Marshal(paymentMetaDataJSON{
+		Amount:                 amount,
+		CurrencyCode:           currencyCode,
+		ApplicationReferenceId: p.ApplicationReferenceId,
+		IsPaymentEngineUsed:    p.IsPaymentEngineUsed,
+	})
+}
+
+// UnmarshalJSON decodes payment metadata whose amount is either a Money object or a legacy number such as 10.5,
+// which is parsed exactly from its decimal representation in the currency given by currencyCode.
+func (p *PaymentMetaData) UnmarshalJSON(data []byte) error {
+	var decoded paymentMetaDataJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var amount Money
+	raw := bytes.TrimSpace(decoded.Amount)
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+		amount.Currency = decoded.CurrencyCode
+	case raw[0] == '{':
+		if err := json.Unmarshal(raw, &amount); err != nil {
+			return fmt.Errorf("invalid payment amount: %v", err)
+		}
+		if amount.Currency == "" {
+			amount.Currency = decoded.CurrencyCode
+		} else if decoded.CurrencyCode != "" && decoded.CurrencyCode != amount.Currency {
+			return fmt.Errorf("currency code %s does not match the currency %s of the payment amount", decoded.CurrencyCode, amount.Currency)
+		}
+	default:
+		// Legacy format: a number, or a string holding one
+		var number json.Number
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return fmt.Errorf("invalid payment amount %s", raw)
+		}
+		// The amount is checked against the currency by CheckPaymentDetails, not when decoding
+		legacy, err := parseDecimal(number.String(), decoded.CurrencyCode)
+		if err != nil {
+			return fmt.Errorf("invalid payment amount: %v", err)
+		}
+		amount = legacy
+	}
+
+	p.Amount = amount
+	p.CurrencyCode = amount.Currency
+	p.ApplicationReferenceId = decoded.ApplicationReferenceId
+	p.IsPaymentEngineUsed = decoded.IsPaymentEngineUsed
+	return nil
+}
+
+// paymentSettable is implemented by transaction contexts that hold the payment of the transaction.
+type paymentSettable interface {
+	SetPayment(payment *PaymentTracker)
+}
+
+// SetPayment sets the payment of the transaction. Payable contracts set it before each transaction, once the
+// payment has been validated.
+func (ctx *TransactionContext) SetPayment(payment *PaymentTracker) {
+	ctx.payment = payment
+}
+
+// GetPayment returns the payment of the transaction, parsed from the last argument of a payable contract function
+// and validated before the function is called.
+//
+// Returns:
+//   - *PaymentTracker: The payment, or nil if the transaction carries no payment.
+func (ctx *TransactionContext) GetPayment() *PaymentTracker {
+	return ctx.payment
+}
+
+// parsePayment parses the payment of the transaction from the last argument of the invoked function. The id and
+// docType fields of the argument identify the asset the payment is made for.
+func parsePayment(ctx TransactionContextInterface) (*PaymentTracker, error) {
+	fnName, args := ctx.GetFunctionAndParameters()
+	if len(args) == 0 {
+		return nil, fmt.Errorf("payment is missing: %s must be called with the payment as its last argument", fnName)
+	}
+
+	var payment PaymentTracker
+	if err := json.Unmarshal([]byte(args[len(args)-1]), &payment); err != nil {
+		return nil, fmt.Errorf("failed to parse the payment in the last argument of %s: %v", fnName, err)
+	}
+	return &payment, nil
+}
+
+// preparePayment parses and validates the payment of the transaction.
+func (c *Contract) preparePayment(ctx TransactionContextInterface) (*PaymentTracker, error) {
+	payment, err := parsePayment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validatePayment(invokedFunction(ctx), *payment); err != nil {
+		return nil, fmt.Errorf("payment rejected: %v", err)
+	}
+	return payment, nil
+}
+
+// checkPayment rejects a transaction of a payable function whose payment is invalid before the function is called,
+// and sets the payment on the transaction context.
+func (c *Contract) checkPayment(ctx TransactionContextInterface) error {
+	if !c.hasPayableFunctions() || !c.isPayable(invokedFunction(ctx)) {
+		return nil
+	}
+
+	payment, err := c.preparePayment(ctx)
+	if err != nil {
+		return err
+	}
+	if settable, ok := ctx.(paymentSettable); ok {
+		settable.SetPayment(payment)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package kalpsdk_test
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk"
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/kalptest"
+)
+
+// shopContract is a payable contract whose functions take the payment as their last argument.
+type shopContract struct {
+	kalpsdk.Contract
+	calls int
+}
+
+func (c *shopContract) Buy(ctx kalpsdk.TransactionContextInterface, item string, payment string) (string, error) {
+	c.calls++
+	if item == "sold out" {
+		return "", fmt.Errorf("%s can not be bought", item)
+	}
+	if err := ctx.PutStateWithoutKYC(item, []byte("sold")); err != nil {
+		return "", err
+	}
+	return ctx.GetPayment().PaymentMetaData.Amount.String(), nil
+}
+
+func (c *shopContract) Restock(ctx kalpsdk.TransactionContextInterface, item string) error {
+	return ctx.PutStateWithoutKYC(item, []byte("in stock"))
+}
+
+func (c *shopContract) Status(ctx kalpsdk.TransactionContextInterface, item string) (string, error) {
+	status, err := ctx.GetState(item)
+	return string(status), err
+}
+
+// newShopHarness returns a harness running the contract, with alice and bob registered for KYC and alice submitting
+// the transactions.
+func newShopHarness(t *testing.T, contract *shopContract) *kalptest.Harness {
+	harness, err := kalptest.NewHarness(contract)
+	require.NoError(t, err)
+	kyc := kalptest.RegisterKYCChaincode(harness.Stub)
+	require.NoError(t, kyc.AddKYC("alice", "kyc1", "hash1"))
+	require.NoError(t, kyc.AddKYC("bob", "kyc2", "hash2"))
+	require.NoError(t, harness.SetIdentity(newIdentity(t, "alice")))
+	return harness
+}
+
+// newIdentity returns a client identity of Org1MSP with the given common name.
+func newIdentity(t *testing.T, name string) *kalptest.Identity {
+	identity, err := kalptest.NewIdentity(kalptest.IdentitySpec{CommonName: name, OrganizationalUnits: []string{"client"}, MSPID: "Org1MSP"})
+	require.NoError(t, err)
+	return identity
+}
+
+// newContext returns a transaction context reading the committed state of the harness.
+func newContext(harness *kalptest.Harness) *kalpsdk.TransactionContext {
+	ctx := &kalpsdk.TransactionContext{}
+	ctx.SetStub(harness.Stub)
+	return ctx
+}
+
+func TestContractRecordsPayment(t *testing.T) {
+	contract := &shopContract{}
+	contract.IsPayableContract = true
+	harness := newShopHarness(t, contract)
+
+	// Check that the after transaction hook records the payment with the writes of the function
+	t.Run("Check for success response", func(t *testing.T) {
+		result := harness.Submit("Buy", "book", `{"id":"book","docType":"ITEM","paymentMetaData":{"amount":2,"currencyCode":"INR"}}`)
+		require.NoError(t, result.Err())
+
+		key, err := harness.Stub.CreateCompositeKey(kalpsdk.PaymentDocType, []string{result.TxID})
+		require.NoError(t, err)
+		keys := make([]string, 0, len(result.WriteSet))
+		for _, write := range result.WriteSet {
+			keys = append(keys, write.Key)
+		}
+		require.Contains(t, keys, "book")
+		require.Contains(t, keys, key)
+
+		payment, err := kalpsdk.GetPaymentRecord(newContext(harness), result.TxID)
+		require.NoError(t, err)
+		require.Equal(t, "2.00 INR", payment.PaymentMetaData.Amount.String())
+	})
+
+	// Check that the after transaction hook does not run when the function fails
+	t.Run("Check for failure response", func(t *testing.T) {
+		result := harness.Submit("Buy", "sold out", `{"paymentMetaData":{"amount":2,"currencyCode":"INR"}}`)
+		require.ErrorContains(t, result.Err(), "sold out can not be bought")
+		require.Empty(t, result.WriteSet)
+
+		payment, err := kalpsdk.GetPaymentRecord(newContext(harness), result.TxID)
+		require.NoError(t, err)
+		require.Nil(t, payment)
+	})
+}
+
+func TestContractPayableFunctions(t *testing.T) {
+	contract := &shopContract{}
+	contract.PayableFunctions = map[string]kalpsdk.PayableFunction{"Buy": {Price: kalpsdk.Money{Units: 2, Currency: "INR"}}}
+	contract.EvaluateTransactions = []string{"Status"}
+	harness := newShopHarness(t, contract)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		// Functions that are not payable take no payment and record none
+		result := harness.Submit("Restock", "book")
+		require.NoError(t, result.Err())
+		require.Equal(t, []kalptest.KVWrite{{Key: "book", Value: []byte("in stock")}}, result.WriteSet)
+
+		result = harness.Evaluate("Status", "book")
+		require.NoError(t, result.Err())
+		require.Equal(t, "in stock", string(result.Response.Payload))
+
+		result = harness.Submit("Buy", "book", `{"paymentMetaData":{"amount":2,"currencyCode":"INR"}}`)
+		require.NoError(t, result.Err())
+		require.Len(t, result.WriteSet, 3)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.ErrorContains(t, harness.Submit("Buy", "pen", `{"paymentMetaData":{"amount":1,"currencyCode":"INR"}}`).Err(), "payment rejected: payment amount 1.00 INR does not match the price 2.00 INR of Buy")
+		require.ErrorContains(t, harness.Submit("Buy", "pen", "pen").Err(), "failed to parse the payment in the last argument of Buy")
+
+		// The payment checks of Contract are not transactions of the contract
+		result := harness.Submit("ValidatePayment", "Buy", `{"paymentMetaData":{"amount":2,"currencyCode":"INR"}}`)
+		require.ErrorContains(t, result.Err(), "Function ValidatePayment not found in contract shopContract")
+		result = harness.Submit("IsPayable", "Buy")
+		require.ErrorContains(t, result.Err(), "Function IsPayable not found in contract shopContract")
+	})
+}
+
+func TestContractPaymentRules(t *testing.T) {
+	contract := &shopContract{}
+	contract.IsPayableContract = true
+	contract.PaymentRules = &kalpsdk.PaymentRules{
+		Currencies: []string{"INR"},
+		Prices:     map[string][]kalpsdk.Money{"Buy": {{Nanos: 500000000, Currency: "INR"}}},
+		Gateways:   []string{"razorpay"},
+	}
+	harness := newShopHarness(t, contract)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		result := harness.Submit("Buy", "book", `{"paymentGatewayName":"razorpay","paymentMetaData":{"amount":0.50,"currencyCode":"INR"}}`)
+		require.NoError(t, result.Err())
+		require.Len(t, result.WriteSet, 3)
+		require.Equal(t, "0.50 INR", string(result.Response.Payload))
+
+		payment, err := kalpsdk.GetPaymentRecord(newContext(harness), result.TxID)
+		require.NoError(t, err)
+		require.Equal(t, kalpsdk.Money{Nanos: 500000000, Currency: "INR"}, payment.PaymentMetaData.Amount)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		tests := map[string]string{
+			`{"paymentGatewayName":"razorpay","paymentMetaData":{"amount":0.50,"currencyCode":"ABC"}}`: `payment rejected: invalid payment currency: currency code "ABC" is not an ISO 4217 currency`,
+			`{"paymentGatewayName":"razorpay","paymentMetaData":{"amount":0.50,"currencyCode":"USD"}}`: "payment rejected: payment currency USD is not accepted, expected one of INR",
+			`{"paymentGatewayName":"razorpay","paymentMetaData":{"amount":1,"currencyCode":"INR"}}`:    "payment rejected: payment amount 1.00 INR does not match the price of Buy, expected 0.50 INR",
+			`{"paymentGatewayName":"paypal","paymentMetaData":{"amount":0.50,"currencyCode":"INR"}}`:   `payment rejected: payment gateway "paypal" is not accepted, expected one of razorpay`,
+			`{"paymentGatewayName":"razorpay","paymentMetaData":`:                                      "failed to parse the payment in the last argument of Buy",
+		}
+		calls := contract.calls
+		for payment, expected := range tests {
+			result := harness.Submit("Buy", "pen", payment)
+			require.ErrorContains(t, result.Err(), expected)
+			require.Empty(t, result.WriteSet)
+		}
+		// The payment is rejected before the function is called
+		require.Equal(t, calls, contract.calls)
+	})
+}
+
+func TestContractPaymentRecords(t *testing.T) {
+	contract := &shopContract{}
+	contract.PayableFunctions = map[string]kalpsdk.PayableFunction{"Buy": {Currency: "INR"}}
+	harness := newShopHarness(t, contract)
+	alice, bob := newIdentity(t, "alice"), newIdentity(t, "bob")
+
+	// The payment of a function is the last argument, with the id and docType of the asset it pays for
+	buy := func(identity *kalptest.Identity, item string, amount string) string {
+		require.NoError(t, harness.SetIdentity(identity))
+		result := harness.Submit("Buy", item, fmt.Sprintf(`{"id":%q,"docType":"ITEM","paymentMetaData":{"amount":%s,"currencyCode":"INR"}}`, item, amount))
+		require.NoError(t, result.Err())
+		return result.TxID
+	}
+	tx1 := buy(alice, "book", "10")
+	tx2 := buy(bob, "book", "12.50")
+	tx3 := buy(alice, "pen", "1")
+
+	// Payments recorded under the raw transaction ID by earlier versions are still found, other records are not
+	harness.Stub.SetCommittedState("tx-legacy", []byte(`{"transactionId":"tx-legacy","DocType":"PAYMENT-INFO","paymentMetaData":{"amount":3,"currencyCode":"INR"}}`))
+	harness.Stub.SetCommittedState("tx-business", []byte(`{"DocType":"ORDER"}`))
+
+	ctx := newContext(harness)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		payment, err := kalpsdk.GetPaymentRecord(ctx, tx2)
+		require.NoError(t, err)
+		require.Equal(t, tx2, payment.TransactionId)
+		require.Equal(t, kalpsdk.PaymentDocType, payment.DocType)
+		require.Equal(t, "bob", payment.Payer)
+		require.Equal(t, "book", payment.AssetId)
+		require.Equal(t, "ITEM", payment.AssetDocType)
+		require.False(t, payment.Timestamp.IsZero())
+		require.Equal(t, "12.50 INR", payment.PaymentMetaData.Amount.String())
+
+		// Payments are not stored under the raw transaction ID
+		value, err := harness.Stub.GetState(tx2)
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		legacy, err := kalpsdk.GetPaymentRecord(ctx, "tx-legacy")
+		require.NoError(t, err)
+		require.Equal(t, "3.00 INR", legacy.PaymentMetaData.Amount.String())
+
+		page, err := kalpsdk.ListPaymentsByAsset(ctx, "book", 1, "")
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+		require.Equal(t, tx1, page.Records[0].TransactionId)
+		require.NotEmpty(t, page.Bookmark)
+
+		page, err = kalpsdk.ListPaymentsByAsset(ctx, "book", 1, page.Bookmark)
+		require.NoError(t, err)
+		require.Len(t, page.Records, 1)
+		require.Equal(t, tx2, page.Records[0].TransactionId)
+
+		page, err = kalpsdk.ListPaymentsByPayer(ctx, "alice", 10, "")
+		require.NoError(t, err)
+		require.Equal(t, int32(2), page.FetchedRecordsCount)
+		require.Equal(t, []string{tx1, tx3}, []string{page.Records[0].TransactionId, page.Records[1].TransactionId})
+
+		page, err = kalpsdk.ListPaymentsByPayer(ctx, "carol", 10, "")
+		require.NoError(t, err)
+		require.Empty(t, page.Records)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		payment, err := kalpsdk.GetPaymentRecord(ctx, "tx-business")
+		require.NoError(t, err)
+		require.Nil(t, payment)
+
+		payment, err = kalpsdk.GetPaymentRecord(ctx, "tx-unknown")
+		require.NoError(t, err)
+		require.Nil(t, payment)
+	})
+}
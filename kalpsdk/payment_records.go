@@ -0,0 +1,206 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+)
+
+// PaymentDocType is the DocType of the payment records written by payable functions. Records are stored under the
+// composite key PAYMENT-INFO/<transaction ID>, so that they cannot collide with the keys of the contract.
+const PaymentDocType = "PAYMENT-INFO"
+
+const (
+	// paymentAssetIndex indexes payment records by asset id, date and transaction ID.
+	paymentAssetIndex = PaymentDocType + "~asset"
+	// paymentPayerIndex indexes payment records by payer, date and transaction ID.
+	paymentPayerIndex = PaymentDocType + "~payer"
+	// paymentDateLayout formats the date attribute of the index keys with a fixed width, so that the keys of a
+	// payer or asset are ordered by date.
+	paymentDateLayout = "2006-01-02T15:04:05.000000000Z"
+)
+
+// paymentIndexValue is the value of the index entries; the entries only carry their key.
+var paymentIndexValue = []byte{0x00}
+
+// putPaymentRecord records the payment of the current transaction, paid by the user that submitted it, with an
+// entry in the asset and payer indexes.
+func putPaymentRecord(ctx TransactionContextInterface, payment PaymentTracker) error {
+	payer, err := ctx.GetUserID()
+	if err != nil {
+		return fmt.Errorf("failed to get the payer of the payment: %v", err)
+	}
+	timestamp, err := ctx.GetTxTimestamp()
+	if err != nil {
+		return fmt.Errorf("failed to get the timestamp of the payment: %v", err)
+	}
+
+	payment.DocType = PaymentDocType
+	payment.TransactionId = ctx.GetTxID()
+	payment.Payer = payer
+	payment.Timestamp = timestamp.AsTime().UTC()
+
+	paymentData, err := json.Marshal(payment)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment %s: %v", payment.TransactionId, err)
+	}
+	key, err := ctx.CreateCompositeKey(PaymentDocType, []string{payment.TransactionId})
+	if err != nil {
+		return err
+	}
+	if err := ctx.PutStateWithKYC(key, paymentData); err != nil {
+		return err
+	}
+
+	date := payment.Timestamp.Format(paymentDateLayout)
+	if err := putPaymentIndexEntry(ctx, paymentPayerIndex, payer, date, payment.TransactionId); err != nil {
+		return err
+	}
+	if payment.AssetId != "" {
+		if err := putPaymentIndexEntry(ctx, paymentAssetIndex, payment.AssetId, date, payment.TransactionId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putPaymentIndexEntry writes the index entry of a payment. The KYC of the payer was checked when the payment record
+// was written.
+func putPaymentIndexEntry(ctx TransactionContextInterface, objectType string, value string, date string, txID string) error {
+	key, err := ctx.CreateCompositeKey(objectType, []string{value, date, txID})
+	if err != nil {
+		return err
+	}
+	return ctx.PutStateWithoutKYC(key, paymentIndexValue)
+}
+
+// GetPaymentRecord returns the payment recorded by a transaction of a payable function. Records written under the raw
+// transaction ID by earlier versions of the SDK are found as well. The payable function itself reads the payment of
+// the current transaction with ctx.GetPayment, as its record is only written once the function succeeds.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - txID: The ID of the transaction that recorded the payment.
+//
+// Returns:
+//   - *PaymentTracker: The payment, or nil if the transaction recorded no payment.
+//   - error: An error if the payment could not be read.
+func GetPaymentRecord(ctx TransactionContextInterface, txID string) (*PaymentTracker, error) {
+	if txID == "" {
+		return nil, fmt.Errorf("transaction id must not be empty")
+	}
+
+	key, err := ctx.CreateCompositeKey(PaymentDocType, []string{txID})
+	if err != nil {
+		return nil, err
+	}
+	payment, err := readPayment(ctx, key)
+	if err != nil || payment != nil {
+		return payment, err
+	}
+
+	// Earlier versions stored the payment under the transaction ID itself, where the contract may store other data
+	data, err := ctx.GetState(txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment %s from world state: %v", txID, err)
+	}
+	var legacy PaymentTracker
+	if data == nil || json.Unmarshal(data, &legacy) != nil || legacy.DocType != PaymentDocType {
+		return nil, nil
+	}
+	return &legacy, nil
+}
+
+// ListPaymentsByAsset returns a page of the payments made for an asset, oldest first. Paginated queries are only
+// allowed in read-only transactions.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - assetID: The id of the asset.
+//   - pageSize: The maximum number of payments to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - *Page[PaymentTracker]: The page of payments.
+//   - error: An error if the payments could not be read.
+func ListPaymentsByAsset(ctx TransactionContextInterface, assetID string, pageSize int32, bookmark string) (*Page[PaymentTracker], error) {
+	if assetID == "" {
+		return nil, fmt.Errorf("asset id must not be empty")
+	}
+	return listPayments(ctx, paymentAssetIndex, assetID, pageSize, bookmark)
+}
+
+// ListPaymentsByPayer returns a page of the payments made by a user, oldest first. Paginated queries are only
+// allowed in read-only transactions.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - userID: The ID of the user, as returned by GetUserID.
+//   - pageSize: The maximum number of payments to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - *Page[PaymentTracker]: The page of payments.
+//   - error: An error if the payments could not be read.
+func ListPaymentsByPayer(ctx TransactionContextInterface, userID string, pageSize int32, bookmark string) (*Page[PaymentTracker], error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user id must not be empty")
+	}
+	return listPayments(ctx, paymentPayerIndex, userID, pageSize, bookmark)
+}
+
+// listPayments returns a page of the payments of an index entry value.
+func listPayments(ctx TransactionContextInterface, objectType string, value string, pageSize int32, bookmark string) (*Page[PaymentTracker], error) {
+	iterator, metadata, err := ctx.GetStateByPartialCompositeKeyWithPagination(objectType, []string{value}, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments of %s: %v", value, err)
+	}
+	defer iterator.Close()
+
+	page := &Page[PaymentTracker]{Records: []PaymentTracker{}}
+	for iterator.HasNext() {
+		entry, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query payments of %s: %v", value, err)
+		}
+		_, attributes, err := ctx.SplitCompositeKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(attributes) != 3 {
+			return nil, fmt.Errorf("invalid payment index key %q", entry.Key)
+		}
+
+		payment, err := GetPaymentRecord(ctx, attributes[2])
+		if err != nil {
+			return nil, err
+		}
+		if payment == nil {
+			return nil, fmt.Errorf("payment %s of %s does not exist", attributes[2], value)
+		}
+		page.Records = append(page.Records, *payment)
+	}
+
+	if metadata != nil {
+		page.FetchedRecordsCount = metadata.FetchedRecordsCount
+		page.Bookmark = metadata.Bookmark
+	}
+	return page, nil
+}
+
+// readPayment reads and decodes the payment stored under a key, or returns nil if there is none.
+func readPayment(ctx TransactionContextInterface, key string) (*PaymentTracker, error) {
+	data, err := ctx.GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payment %s from world state: %v", key, err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var payment PaymentTracker
+	if err := json.Unmarshal(data, &payment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payment %s: %v", key, err)
+	}
+	return &payment, nil
+}
@@ -0,0 +1,64 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPaymentRecord(t *testing.T) {
+	newContext := func(txID string, record []byte, legacy []byte) *TransactionContext {
+		mockStub := new(mocks.ChaincodeStubInterface)
+		mockStub.On("CreateCompositeKey", PaymentDocType, []string{txID}).Return("\x00PAYMENT-INFO\x00"+txID+"\x00", nil)
+		mockStub.On("GetState", "\x00PAYMENT-INFO\x00"+txID+"\x00").Return(record, nil)
+		mockStub.On("GetState", txID).Return(legacy, nil)
+		return &TransactionContext{stub: mockStub}
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		ctx := newContext("tx1", []byte(`{"transactionId":"tx1","DocType":"PAYMENT-INFO","payer":"alice","paymentMetaData":{"amount":"10","currencyCode":"INR"}}`), nil)
+		payment, err := GetPaymentRecord(ctx, "tx1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", payment.Payer)
+		require.Equal(t, Money{Units: 10, Currency: "INR"}, payment.PaymentMetaData.Amount)
+
+		// Payments recorded under the raw transaction ID are found
+		ctx = newContext("tx2", nil, []byte(`{"transactionId":"tx2","DocType":"PAYMENT-INFO","paymentMetaData":{"amount":2.5,"currencyCode":"INR"}}`))
+		payment, err = GetPaymentRecord(ctx, "tx2")
+		require.NoError(t, err)
+		require.Equal(t, "tx2", payment.TransactionId)
+
+		// Other records under the raw transaction ID are not payments
+		for _, legacy := range [][]byte{nil, []byte(`{"DocType":"ORDER"}`), []byte("not json")} {
+			payment, err = GetPaymentRecord(newContext("tx3", nil, legacy), "tx3")
+			require.NoError(t, err)
+			require.Nil(t, payment)
+		}
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := GetPaymentRecord(&TransactionContext{}, "")
+		require.EqualError(t, err, "transaction id must not be empty")
+
+		_, err = GetPaymentRecord(newContext("tx1", []byte("not json"), nil), "tx1")
+		require.ErrorContains(t, err, "failed to unmarshal payment")
+
+		mockStub := new(mocks.ChaincodeStubInterface)
+		mockStub.On("CreateCompositeKey", PaymentDocType, []string{"tx1"}).Return("key", nil)
+		mockStub.On("GetState", "key").Return(nil, fmt.Errorf("ledger unavailable"))
+		_, err = GetPaymentRecord(&TransactionContext{stub: mockStub}, "tx1")
+		require.EqualError(t, err, "failed to read payment key from world state: ledger unavailable")
+
+		_, err = ListPaymentsByAsset(&TransactionContext{}, "", 10, "")
+		require.EqualError(t, err, "asset id must not be empty")
+
+		_, err = ListPaymentsByPayer(&TransactionContext{}, "", 10, "")
+		require.EqualError(t, err, "user id must not be empty")
+	})
+}
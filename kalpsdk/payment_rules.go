@@ -0,0 +1,209 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"sort"
+	"strings"
+
+	//Third party Libs
+	"golang.org/x/exp/slices"
+)
+
+// PaymentRules restrict the payments accepted by a payable contract. Every rule that is set must be met; a zero
+// PaymentRules accepts any positive amount in an ISO 4217 currency.
+type PaymentRules struct {
+	Currencies []string               // Accepted currencies, as ISO 4217 codes; empty accepts every currency.
+	Limits     map[string]AmountLimit // Minimum and maximum amounts by currency code.
+	Prices     map[string][]Money     // Accepted amounts by function name; a function without prices accepts any amount.
+	Gateways   []string               // Accepted payment gateway names; empty accepts any gateway.
+}
+
+// AmountLimit bounds the amounts of a currency accepted by PaymentRules. The currency of Min and Max may be left
+// empty, in which case it is the currency the limit is set for.
+type AmountLimit struct {
+	Min Money // The minimum amount, inclusive; a zero Min only requires a positive amount.
+	Max Money // The maximum amount, inclusive; a zero Max sets no maximum.
+}
+
+// Validate checks that the rules are consistent: every currency is an ISO 4217 currency accepted by the rules,
+// every amount is valid and no minimum exceeds its maximum.
+//
+// Returns:
+//   - error: An error describing the first invalid rule.
+func (r PaymentRules) Validate() error {
+	for _, currency := range r.Currencies {
+		if err := ValidateCurrency(currency); err != nil {
+			return fmt.Errorf("accepted currencies: %v", err)
+		}
+	}
+
+	for _, currency := range sortedKeys(r.Limits) {
+		if err := r.checkRuleCurrency(currency); err != nil {
+			return fmt.Errorf("limit of %s: %v", currency, err)
+		}
+		limit := r.Limits[currency]
+		min, err := limit.bound(limit.Min, currency)
+		if err != nil {
+			return fmt.Errorf("minimum amount of %s: %v", currency, err)
+		}
+		max, err := limit.bound(limit.Max, currency)
+		if err != nil {
+			return fmt.Errorf("maximum amount of %s: %v", currency, err)
+		}
+		if !max.IsZero() {
+			if cmp, _ := min.Compare(max); cmp > 0 {
+				return fmt.Errorf("minimum amount %s exceeds the maximum amount %s", min, max)
+			}
+		}
+	}
+
+	for _, function := range sortedKeys(r.Prices) {
+		for _, price := range r.Prices[function] {
+			if err := r.checkRuleCurrency(price.Currency); err != nil {
+				return fmt.Errorf("price of %s: %v", function, err)
+			}
+			if err := price.Validate(); err != nil {
+				return fmt.Errorf("price of %s: %v", function, err)
+			}
+			if !price.IsPositive() {
+				return fmt.Errorf("price %s of %s must be positive", price, function)
+			}
+		}
+	}
+
+	for _, gateway := range r.Gateways {
+		if strings.TrimSpace(gateway) == "" {
+			return fmt.Errorf("accepted payment gateway names must not be empty")
+		}
+	}
+	return nil
+}
+
+// Check checks that a payment made to a function meets the rules. The payment amount must already be valid, as
+// checked by validatePayment.
+//
+// Parameters:
+//   - function: The name of the function the payment is made to.
+//   - payment: The payment.
+//
+// Returns:
+//   - error: An error describing the first rule the payment does not meet, or an error if the rules are invalid.
+func (r PaymentRules) Check(function string, payment PaymentTracker) error {
+	if err := r.Validate(); err != nil {
+		return fmt.Errorf("invalid payment rules: %v", err)
+	}
+
+	amount := payment.PaymentMetaData.Amount
+	if len(r.Currencies) > 0 && !slices.Contains(r.Currencies, amount.Currency) {
+		return fmt.Errorf("payment currency %s is not accepted, expected one of %s", amount.Currency, strings.Join(r.Currencies, ", "))
+	}
+
+	if limit, ok := r.Limits[amount.Currency]; ok {
+		// The limits were checked by Validate
+		min, _ := limit.bound(limit.Min, amount.Currency)
+		max, _ := limit.bound(limit.Max, amount.Currency)
+		if cmp, _ := amount.Compare(min); cmp < 0 {
+			return fmt.Errorf("payment amount %s is below the minimum of %s", amount, min)
+		}
+		if cmp, _ := amount.Compare(max); !max.IsZero() && cmp > 0 {
+			return fmt.Errorf("payment amount %s exceeds the maximum of %s", amount, max)
+		}
+	}
+
+	if prices, ok := r.Prices[function]; ok {
+		if !slices.ContainsFunc(prices, amount.Equal) {
+			expected := make([]string, 0, len(prices))
+			for _, price := range prices {
+				expected = append(expected, price.String())
+			}
+			return fmt.Errorf("payment amount %s does not match the price of %s, expected %s", amount, function, strings.Join(expected, " or "))
+		}
+	}
+
+	if len(r.Gateways) > 0 && !slices.Contains(r.Gateways, payment.PaymentGatewayName) {
+		return fmt.Errorf("payment gateway %q is not accepted, expected one of %s", payment.PaymentGatewayName, strings.Join(r.Gateways, ", "))
+	}
+	return nil
+}
+
+// checkRuleCurrency checks that a currency used by a limit or price is valid and accepted by the rules.
+func (r PaymentRules) checkRuleCurrency(currency string) error {
+	if err := ValidateCurrency(currency); err != nil {
+		return err
+	}
+	if len(r.Currencies) > 0 && !slices.Contains(r.Currencies, currency) {
+		return fmt.Errorf("currency %s is not accepted", currency)
+	}
+	return nil
+}
+
+// bound returns a minimum or maximum amount in the currency of the limit.
+func (l AmountLimit) bound(amount Money, currency string) (Money, error) {
+	if amount.Currency == "" {
+		amount.Currency = currency
+	} else if amount.Currency != currency {
+		return Money{}, fmt.Errorf("amount %s is not in %s", amount, currency)
+	}
+	if err := amount.Validate(); err != nil {
+		return Money{}, err
+	}
+	if amount.IsNegative() {
+		return Money{}, fmt.Errorf("amount %s must not be negative", amount)
+	}
+	return amount, nil
+}
+
+// validatePayment checks a payment made to a function of the contract: the amount must be a valid, positive amount
+// of an ISO 4217 currency, and the payment must meet the PaymentRules of the contract and the price and currency of
+// the function in PayableFunctions, if any. It is unexported, as contractapi would register an exported method of
+// Contract as a transaction of every contract embedding it.
+//
+// Parameters:
+//   - function: The name of the function the payment is made to.
+//   - payment: The payment.
+//
+// Returns:
+//   - error: An error describing why the payment is not accepted.
+func (c *Contract) validatePayment(function string, payment PaymentTracker) error {
+	if err := checkPaymentDetails(payment); err != nil {
+		return err
+	}
+	if c.PaymentRules != nil {
+		if err := c.PaymentRules.Check(function, payment); err != nil {
+			return err
+		}
+	}
+	if payable, ok := c.PayableFunctions[function]; ok {
+		return payable.Check(function, payment)
+	}
+	return nil
+}
+
+// checkPaymentDetails checks that the payment has a valid, positive amount in an ISO 4217 currency.
+func checkPaymentDetails(payment PaymentTracker) error {
+	amount := payment.PaymentMetaData.Amount
+	if err := ValidateCurrency(amount.Currency); err != nil {
+		return fmt.Errorf("invalid payment currency: %v", err)
+	}
+	if currencyCode := payment.PaymentMetaData.CurrencyCode; currencyCode != "" && currencyCode != amount.Currency {
+		return fmt.Errorf("currency code %s does not match the currency %s of the payment amount", currencyCode, amount.Currency)
+	}
+	if err := amount.Validate(); err != nil {
+		return fmt.Errorf("invalid payment amount: %v", err)
+	}
+	if !amount.IsPositive() {
+		return fmt.Errorf("payment amount %s must be positive", amount)
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in increasing order, so that the reported error does not vary between peers.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
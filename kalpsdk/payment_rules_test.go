@@ -0,0 +1,101 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+// testPayment returns a payment of `amount` through the payment gateway `gateway`.
+func testPayment(t *testing.T, amount string, currency string, gateway string) PaymentTracker {
+	m, err := ParseMoney(amount, currency)
+	require.NoError(t, err)
+	return PaymentTracker{PaymentGatewayName: gateway, PaymentMetaData: PaymentMetaData{Amount: m, CurrencyCode: currency}}
+}
+
+func TestPaymentRulesValidate(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, PaymentRules{}.Validate())
+		require.NoError(t, PaymentRules{
+			Currencies: []string{"INR", "USD"},
+			Limits: map[string]AmountLimit{
+				"INR": {Min: Money{Units: 1}, Max: Money{Units: 1000, Currency: "INR"}},
+				"USD": {Max: Money{Units: 10}},
+			},
+			Prices:   map[string][]Money{"Buy": {{Units: 10, Currency: "INR"}}},
+			Gateways: []string{"razorpay"},
+		}.Validate())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		tests := []struct {
+			rules    PaymentRules
+			expected string
+		}{
+			{PaymentRules{Currencies: []string{"INR", "RS"}}, `accepted currencies: currency code "RS" is not an ISO 4217 currency`},
+			{PaymentRules{Limits: map[string]AmountLimit{"usd": {}}}, `limit of usd: currency code "usd" must be in upper case, USD`},
+			{PaymentRules{Currencies: []string{"INR"}, Limits: map[string]AmountLimit{"USD": {}}}, "limit of USD: currency USD is not accepted"},
+			{PaymentRules{Limits: map[string]AmountLimit{"INR": {Min: Money{Units: 1, Currency: "USD"}}}}, "minimum amount of INR: amount 1.00 USD is not in INR"},
+			{PaymentRules{Limits: map[string]AmountLimit{"INR": {Max: Money{Units: -1}}}}, "maximum amount of INR: amount -1.00 INR must not be negative"},
+			{PaymentRules{Limits: map[string]AmountLimit{"JPY": {Min: Money{Nanos: 500000000}}}}, "minimum amount of JPY: amount 0.5 is more precise than the 0 decimal places of JPY"},
+			{PaymentRules{Limits: map[string]AmountLimit{"INR": {Min: Money{Units: 10}, Max: Money{Units: 5}}}}, "minimum amount 10.00 INR exceeds the maximum amount 5.00 INR"},
+			{PaymentRules{Currencies: []string{"INR"}, Prices: map[string][]Money{"Buy": {{Units: 1, Currency: "EUR"}}}}, "price of Buy: currency EUR is not accepted"},
+			{PaymentRules{Prices: map[string][]Money{"Buy": {{Currency: "INR"}}}}, "price 0.00 INR of Buy must be positive"},
+			{PaymentRules{Gateways: []string{" "}}, "accepted payment gateway names must not be empty"},
+		}
+		for _, tc := range tests {
+			require.EqualError(t, tc.rules.Validate(), tc.expected)
+		}
+	})
+}
+
+func TestPaymentRulesCheck(t *testing.T) {
+	rules := PaymentRules{
+		Currencies: []string{"INR", "USD"},
+		Limits: map[string]AmountLimit{
+			"INR": {Min: Money{Units: 1}, Max: Money{Units: 1000}},
+		},
+		Prices:   map[string][]Money{"Buy": {{Units: 10, Currency: "INR"}, {Units: 1, Nanos: 500000000, Currency: "USD"}}},
+		Gateways: []string{"razorpay", "stripe"},
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NoError(t, rules.Check("Donate", testPayment(t, "1", "INR", "razorpay")))
+		require.NoError(t, rules.Check("Donate", testPayment(t, "1000", "INR", "stripe")))
+		require.NoError(t, rules.Check("Donate", testPayment(t, "0.01", "USD", "stripe")))
+		require.NoError(t, rules.Check("Buy", testPayment(t, "10.00", "INR", "razorpay")))
+		require.NoError(t, rules.Check("Buy", testPayment(t, "1.5", "USD", "razorpay")))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		require.EqualError(t, rules.Check("Donate", testPayment(t, "1", "EUR", "razorpay")), "payment currency EUR is not accepted, expected one of INR, USD")
+		require.EqualError(t, rules.Check("Donate", testPayment(t, "0.50", "INR", "razorpay")), "payment amount 0.50 INR is below the minimum of 1.00 INR")
+		require.EqualError(t, rules.Check("Donate", testPayment(t, "1000.01", "INR", "razorpay")), "payment amount 1000.01 INR exceeds the maximum of 1000.00 INR")
+		require.EqualError(t, rules.Check("Buy", testPayment(t, "5", "INR", "razorpay")), "payment amount 5.00 INR does not match the price of Buy, expected 10.00 INR or 1.50 USD")
+		require.EqualError(t, rules.Check("Donate", testPayment(t, "5", "INR", "paypal")), `payment gateway "paypal" is not accepted, expected one of razorpay, stripe`)
+		require.EqualError(t, PaymentRules{Currencies: []string{"XYZ"}}.Check("Donate", testPayment(t, "5", "INR", "")), `invalid payment rules: accepted currencies: currency code "XYZ" is not an ISO 4217 currency`)
+	})
+}
+
+func TestContractValidatePayment(t *testing.T) {
+	contract := &Contract{}
+
+	// Check for success response
+	require.NoError(t, contract.validatePayment("Buy", testPayment(t, "0.50", "INR", "")))
+
+	// Check for failure response
+	require.EqualError(t, contract.validatePayment("Buy", PaymentTracker{}), "invalid payment currency: currency code must not be empty")
+	require.EqualError(t, contract.validatePayment("Buy", PaymentTracker{PaymentMetaData: PaymentMetaData{Amount: Money{Units: 1, Currency: "KLP"}}}), `invalid payment currency: currency code "KLP" is not an ISO 4217 currency`)
+	require.EqualError(t, contract.validatePayment("Buy", PaymentTracker{PaymentMetaData: PaymentMetaData{Amount: Money{Units: 1, Currency: "INR"}, CurrencyCode: "USD"}}), "currency code USD does not match the currency INR of the payment amount")
+	require.EqualError(t, contract.validatePayment("Buy", PaymentTracker{PaymentMetaData: PaymentMetaData{Amount: Money{Nanos: 5, Currency: "INR"}}}), "invalid payment amount: amount 0.000000005 is more precise than the 2 decimal places of INR")
+	require.EqualError(t, contract.validatePayment("Buy", testPayment(t, "-1", "INR", "")), "payment amount -1.00 INR must be positive")
+
+	contract.PaymentRules = &PaymentRules{Gateways: []string{"razorpay"}}
+	require.EqualError(t, contract.validatePayment("Buy", testPayment(t, "1", "INR", "")), `payment gateway "" is not accepted, expected one of razorpay`)
+}
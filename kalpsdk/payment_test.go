@@ -0,0 +1,115 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentMetaDataJSON(t *testing.T) {
+	// Check that the legacy float format is parsed exactly
+	t.Run("Check for legacy format", func(t *testing.T) {
+		var tracker PaymentTracker
+		require.NoError(t, json.Unmarshal([]byte(`{"paymentTransactionId":"pay1","paymentMetaData":{"amount":0.1,"currencyCode":"INR","applicationReferenceId":"app1"}}`), &tracker))
+		require.Equal(t, Money{Nanos: 100000000, Currency: "INR"}, tracker.PaymentMetaData.Amount)
+		require.Equal(t, "INR", tracker.PaymentMetaData.CurrencyCode)
+		require.Equal(t, "app1", tracker.PaymentMetaData.ApplicationReferenceId)
+
+		var metaData PaymentMetaData
+		require.NoError(t, json.Unmarshal([]byte(`{"amount":"1234.56","currencyCode":"INR"}`), &metaData))
+		require.Equal(t, Money{Units: 1234, Nanos: 560000000, Currency: "INR"}, metaData.Amount)
+	})
+
+	// Check that the Money format round-trips
+	t.Run("Check for success response", func(t *testing.T) {
+		metaData := PaymentMetaData{Amount: Money{Units: 10, Nanos: 500000000, Currency: "INR"}, ApplicationReferenceId: "app1", IsPaymentEngineUsed: true}
+		data, err := json.Marshal(metaData)
+		require.NoError(t, err)
+		require.Equal(t, `{"amount":{"units":10,"nanos":500000000,"currency":"INR"},"currencyCode":"INR","applicationReferenceId":"app1","isPaymentEngineUsed":true}`, string(data))
+
+		var decoded PaymentMetaData
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		metaData.CurrencyCode = "INR"
+		require.Equal(t, metaData, decoded)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"amount":{"units":2,"nanos":0},"currencyCode":"USD"}`), &decoded))
+		require.Equal(t, Money{Units: 2, Currency: "USD"}, decoded.Amount)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		var metaData PaymentMetaData
+		require.EqualError(t, json.Unmarshal([]byte(`{"amount":{"units":2,"currency":"USD"},"currencyCode":"INR"}`), &metaData), "currency code INR does not match the currency USD of the payment amount")
+		require.EqualError(t, json.Unmarshal([]byte(`{"amount":true,"currencyCode":"INR"}`), &metaData), "invalid payment amount true")
+		require.EqualError(t, json.Unmarshal([]byte(`{"amount":"ten","currencyCode":"INR"}`), &metaData), `invalid payment amount "ten"`)
+	})
+}
+
+func TestCheckPaymentDetails(t *testing.T) {
+	c := new(Contract)
+	tracker := func(metaData string) PaymentTracker {
+		var tracker PaymentTracker
+		require.NoError(t, json.Unmarshal([]byte(`{"paymentMetaData":`+metaData+`}`), &tracker))
+		return tracker
+	}
+
+	// Check for success response
+	require.True(t, c.CheckPaymentDetails(tracker(`{"amount":0.50,"currencyCode":"INR"}`)))
+	require.True(t, c.CheckPaymentDetails(tracker(`{"amount":{"units":100,"currency":"JPY"}}`)))
+
+	// Check for failure response
+	require.False(t, c.CheckPaymentDetails(tracker(`{"amount":0,"currencyCode":"INR"}`)))
+	require.False(t, c.CheckPaymentDetails(tracker(`{"amount":-5,"currencyCode":"INR"}`)))
+	require.False(t, c.CheckPaymentDetails(tracker(`{"amount":10}`)))
+	require.False(t, c.CheckPaymentDetails(tracker(`{"amount":10,"currencyCode":"KLP"}`)))
+	require.False(t, c.CheckPaymentDetails(tracker(`{"amount":0.505,"currencyCode":"INR"}`)))
+	require.False(t, c.CheckPaymentDetails(PaymentTracker{PaymentMetaData: PaymentMetaData{Amount: Money{Units: 1, Currency: "INR"}, CurrencyCode: "USD"}}))
+}
+
+func TestCheckPayment(t *testing.T) {
+	contract := &Contract{IsPayableContract: true, PaymentRules: &PaymentRules{Currencies: []string{"INR"}}}
+	newContext := func(function string, args ...string) *TransactionContext {
+		mockStub := new(mocks.ChaincodeStubInterface)
+		mockStub.On("GetFunctionAndParameters").Return(function, args)
+		return &TransactionContext{stub: mockStub}
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		ctx := newContext("Shop:Buy", "book", `{"id":"book1","docType":"BOOK","paymentGatewayName":"razorpay","paymentMetaData":{"amount":0.50,"currencyCode":"INR"}}`)
+		require.NoError(t, contract.checkPayment(ctx))
+		require.Equal(t, &PaymentTracker{
+			PaymentGatewayName: "razorpay",
+			PaymentMetaData:    PaymentMetaData{Amount: Money{Nanos: 500000000, Currency: "INR"}, CurrencyCode: "INR"},
+			AssetId:            "book1",
+			AssetDocType:       "BOOK",
+		}, ctx.GetPayment())
+
+		// Contracts that are not payable do not read the arguments
+		ctx = &TransactionContext{}
+		require.NoError(t, new(Contract).checkPayment(ctx))
+		require.Nil(t, ctx.GetPayment())
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		ctx := newContext("Buy")
+		require.EqualError(t, contract.checkPayment(ctx), "payment is missing: Buy must be called with the payment as its last argument")
+		require.Nil(t, ctx.GetPayment())
+
+		ctx = newContext("Buy", "book", "not json")
+		require.ErrorContains(t, contract.checkPayment(ctx), "failed to parse the payment in the last argument of Buy")
+		require.Nil(t, ctx.GetPayment())
+
+		ctx = newContext("Buy", `{"paymentMetaData":{"amount":"ten","currencyCode":"INR"}}`)
+		require.EqualError(t, contract.checkPayment(ctx), `failed to parse the payment in the last argument of Buy: invalid payment amount "ten"`)
+
+		ctx = newContext("Buy", `{"paymentMetaData":{"amount":5,"currencyCode":"USD"}}`)
+		require.EqualError(t, contract.checkPayment(ctx), "payment rejected: payment currency USD is not accepted, expected one of INR")
+		require.Nil(t, ctx.GetPayment())
+	})
+}
@@ -0,0 +1,184 @@
+package kalpsdk
+
+// GetPrivateData returns the value of the specified `key` from the specified `collection`. Note that
+// GetPrivateData doesn't read data from the private writeset, which has not been committed to the `collection`.
+// In other words, GetPrivateData doesn't consider data modified by PutPrivateData that has not been committed.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//
+// Returns:
+//   - []byte: The value of the key, or nil if the key does not exist.
+//   - error: An error if the private data could not be read.
+func (ctx *TransactionContext) GetPrivateData(collection string, key string) ([]byte, error) {
+	return ctx.GetStub().GetPrivateData(collection, key)
+}
+
+// GetPrivateDataHash returns the hash of the value of the specified `key` from the specified `collection`.
+// Unlike GetPrivateData, it can be called by peers of organizations that are not members of the collection.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data.
+//
+// Returns:
+//   - []byte: The hash of the value, or nil if the key does not exist.
+//   - error: An error if the hash could not be read.
+func (ctx *TransactionContext) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	return ctx.GetStub().GetPrivateDataHash(collection, key)
+}
+
+// PutPrivateDataWithKYC puts the specified `key` and `value` into the transaction's private writeset of the
+// specified `collection`, only if the user has completed KYC. If the user has not completed KYC, an error is
+// returned. Only the hash of the private writeset goes into the transaction proposal response; the actual private
+// data is distributed to the peers of the collection members.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key under which the data will be stored in the collection.
+//   - value: The data to be stored in the collection as a byte array.
+//
+// Returns:
+//   - error: An error if the operation fails or if the user has not completed KYC.
+func (ctx *TransactionContext) PutPrivateDataWithKYC(collection string, key string, value []byte) error {
+	// Check the KYC of the user.
+	if err := ctx.RequireKYCLevel(KYCLevelBasic); err != nil {
+		return err
+	}
+
+	// Put the private data into the transaction's private writeset.
+	return ctx.GetStub().PutPrivateData(collection, key, value)
+}
+
+// PutPrivateDataWithoutKYC puts the specified `key` and `value` into the transaction's private writeset of the
+// specified `collection` without requiring KYC verification. Use this function with caution as it may bypass
+// security and compliance measures. It is recommended to use PutPrivateDataWithKYC instead.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key under which the data will be stored in the collection.
+//   - value: The data to be stored in the collection as a byte array.
+//
+// Returns:
+//   - error: An error if the operation fails.
+func (ctx *TransactionContext) PutPrivateDataWithoutKYC(collection string, key string, value []byte) error {
+	return ctx.GetStub().PutPrivateData(collection, key, value)
+}
+
+// DelPrivateDataWithKYC records the specified `key` to be deleted in the private writeset of the specified
+// `collection`, only if the user has completed KYC. The `key` and its value will be deleted from the collection
+// when the transaction is validated and successfully committed.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data to be deleted.
+//
+// Returns:
+//   - error: An error if the deletion fails or if the user has not completed KYC.
+func (ctx *TransactionContext) DelPrivateDataWithKYC(collection string, key string) error {
+	// Check the KYC of the user.
+	if err := ctx.RequireKYCLevel(KYCLevelBasic); err != nil {
+		return err
+	}
+
+	// Delete the private data from the collection.
+	return ctx.GetStub().DelPrivateData(collection, key)
+}
+
+// DelPrivateDataWithoutKYC records the specified `key` to be deleted in the private writeset of the specified
+// `collection` without requiring KYC verification. Use this function with caution as it may bypass security and
+// compliance measures. It is recommended to use DelPrivateDataWithKYC instead.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data to be deleted.
+//
+// Returns:
+//   - error: An error if the deletion fails.
+func (ctx *TransactionContext) DelPrivateDataWithoutKYC(collection string, key string) error {
+	return ctx.GetStub().DelPrivateData(collection, key)
+}
+
+// PurgePrivateDataWithKYC records the specified `key` to be purged in the private writeset of the specified
+// `collection`, only if the user has completed KYC. Unlike a delete, the purge also removes the historical
+// versions of the key from the private data store of the collection members.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data to be purged.
+//
+// Returns:
+//   - error: An error if the purge fails or if the user has not completed KYC.
+func (ctx *TransactionContext) PurgePrivateDataWithKYC(collection string, key string) error {
+	// Check the KYC of the user.
+	if err := ctx.RequireKYCLevel(KYCLevelBasic); err != nil {
+		return err
+	}
+
+	// Purge the private data from the collection.
+	return ctx.GetStub().PurgePrivateData(collection, key)
+}
+
+// PurgePrivateDataWithoutKYC records the specified `key` to be purged in the private writeset of the specified
+// `collection` without requiring KYC verification. Use this function with caution as it may bypass security and
+// compliance measures. It is recommended to use PurgePrivateDataWithKYC instead.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - key: The key of the private data to be purged.
+//
+// Returns:
+//   - error: An error if the purge fails.
+func (ctx *TransactionContext) PurgePrivateDataWithoutKYC(collection string, key string) error {
+	return ctx.GetStub().PurgePrivateData(collection, key)
+}
+
+// GetPrivateDataByRange returns a range iterator over a set of keys in the specified `collection`. The iterator
+// can be used to iterate over all keys between the startKey (inclusive) and endKey (exclusive), in lexical order.
+// Note that startKey and endKey can be empty string, which implies unbounded range query on start or end.
+// Call Close() on the returned StateQueryIteratorInterface object when done.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - startKey: The first key of the range.
+//   - endKey: The key after the last key of the range.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the keys of the range.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetPrivateDataByRange(collection string, startKey string, endKey string) (StateQueryIteratorInterface, error) {
+	return ctx.GetStub().GetPrivateDataByRange(collection, startKey, endKey)
+}
+
+// GetPrivateDataByPartialCompositeKey queries the state in the specified `collection` based on a given partial
+// composite key. The returned iterator can be used to iterate over all composite keys whose prefix matches the
+// given partial composite key. Call Close() on the returned StateQueryIteratorInterface object when done.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - objectType: The object type of the composite keys.
+//   - keys: The leading attributes of the composite keys.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the matching keys.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetPrivateDataByPartialCompositeKey(collection string, objectType string, keys []string) (StateQueryIteratorInterface, error) {
+	return ctx.GetStub().GetPrivateDataByPartialCompositeKey(collection, objectType, keys)
+}
+
+// GetPrivateDataQueryResult performs a "rich" query against the specified `collection`. It is only supported for
+// state databases that support rich query, e.g. CouchDB. The query string is in the native syntax of the
+// underlying state database. The query is NOT re-executed during validation phase, phantom reads are not detected,
+// so it should be limited to read-only chaincode operations.
+//
+// Parameters:
+//   - collection: The name of the private data collection.
+//   - query: The query in the syntax of the state database.
+//
+// Returns:
+//   - StateQueryIteratorInterface: The iterator over the query results.
+//   - error: An error if the query fails.
+func (ctx *TransactionContext) GetPrivateDataQueryResult(collection string, query string) (StateQueryIteratorInterface, error) {
+	return ctx.GetStub().GetPrivateDataQueryResult(collection, query)
+}
@@ -0,0 +1,144 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const testCollection = "collectionOrg1"
+
+func TestGetPrivateData(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetPrivateData", testCollection, "key").Return([]byte("value"), nil).Once()
+		mockStub.On("GetPrivateDataHash", testCollection, "key").Return([]byte("hash"), nil).Once()
+
+		value, err := tx.GetPrivateData(testCollection, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		hash, err := tx.GetPrivateDataHash(testCollection, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("hash"), hash)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetPrivateData", testCollection, "key").Return(nil, fmt.Errorf("collection not found")).Once()
+
+		_, err := tx.GetPrivateData(testCollection, "key")
+		require.EqualError(t, err, "collection not found")
+	})
+}
+
+func TestPrivateDataWithoutKYC(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("PutPrivateData", testCollection, "key", []byte("value")).Return(nil).Once()
+		mockStub.On("DelPrivateData", testCollection, "key").Return(nil).Once()
+		mockStub.On("PurgePrivateData", testCollection, "key").Return(nil).Once()
+
+		require.NoError(t, tx.PutPrivateDataWithoutKYC(testCollection, "key", []byte("value")))
+		require.NoError(t, tx.DelPrivateDataWithoutKYC(testCollection, "key"))
+		require.NoError(t, tx.PurgePrivateDataWithoutKYC(testCollection, "key"))
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("PutPrivateData", testCollection, "key", []byte("value")).Return(fmt.Errorf("failed to put private data")).Once()
+
+		err := tx.PutPrivateDataWithoutKYC(testCollection, "key", []byte("value"))
+		require.EqualError(t, err, "failed to put private data")
+	})
+}
+
+func TestPrivateDataWithKYC(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	mockClientIdentity := new(mocks.ClientIdentity)
+	kycLevel := KYCLevelBasic
+	tx := &TransactionContext{
+		stub:           mockStub,
+		clientIdentity: mockClientIdentity,
+		kycProvider: KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+			return KYCStatus{Level: kycLevel}, nil
+		}),
+	}
+	mockClientIdentity.On("GetID").Return(testOwnerID, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx1").Times(3)
+		mockStub.On("PutPrivateData", testCollection, "key", []byte("value")).Return(nil).Once()
+		mockStub.On("DelPrivateData", testCollection, "key").Return(nil).Once()
+		mockStub.On("PurgePrivateData", testCollection, "key").Return(nil).Once()
+
+		require.NoError(t, tx.PutPrivateDataWithKYC(testCollection, "key", []byte("value")))
+		require.NoError(t, tx.DelPrivateDataWithKYC(testCollection, "key"))
+		require.NoError(t, tx.PurgePrivateDataWithKYC(testCollection, "key"))
+		mockStub.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		kycLevel = KYCLevelNone
+		mockStub.On("GetTxID").Return("tx2")
+
+		for _, err := range []error{
+			tx.PutPrivateDataWithKYC(testCollection, "key", []byte("value")),
+			tx.DelPrivateDataWithKYC(testCollection, "key"),
+			tx.PurgePrivateDataWithKYC(testCollection, "key"),
+		} {
+			require.EqualError(t, err, "access denied: user TestOwner has not completed KYC")
+		}
+	})
+}
+
+func TestPrivateDataQueries(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	mockIterator := new(mocks.StateQueryIteratorInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetPrivateDataByRange", testCollection, "a", "z").Return(mockIterator, nil).Once()
+		mockStub.On("GetPrivateDataByPartialCompositeKey", testCollection, "NIU", []string{"alice"}).Return(mockIterator, nil).Once()
+		mockStub.On("GetPrivateDataQueryResult", testCollection, `{"selector":{}}`).Return(mockIterator, nil).Once()
+
+		it, err := tx.GetPrivateDataByRange(testCollection, "a", "z")
+		require.NoError(t, err)
+		require.Equal(t, mockIterator, it)
+
+		it, err = tx.GetPrivateDataByPartialCompositeKey(testCollection, "NIU", []string{"alice"})
+		require.NoError(t, err)
+		require.Equal(t, mockIterator, it)
+
+		it, err = tx.GetPrivateDataQueryResult(testCollection, `{"selector":{}}`)
+		require.NoError(t, err)
+		require.Equal(t, mockIterator, it)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetPrivateDataByRange", testCollection, "a", "z").Return(nil, fmt.Errorf("collection not found")).Once()
+
+		_, err := tx.GetPrivateDataByRange(testCollection, "a", "z")
+		require.EqualError(t, err, "collection not found")
+	})
+}
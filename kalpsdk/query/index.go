@@ -0,0 +1,280 @@
+package query
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	//Third party Libs
+	"golang.org/x/exp/slices"
+)
+
+// TagName is the struct tag declaring the CouchDB indexes a field belongs to, e.g. `kalp:"index=byOwner"`. Several
+// indexes are separated by commas, e.g. `kalp:"index=byOwner,index=byStatus"`. The fields of an index are taken in
+// the order they are declared in the struct.
+const TagName = "kalp"
+
+// Index is a CouchDB JSON index definition, as read by the peer from the META-INF/statedb/couchdb/indexes folder
+// of the chaincode package.
+type Index struct {
+	Name   string   // The name of the index.
+	DDoc   string   // The design document holding the index.
+	Fields []string // The indexed fields, in order.
+}
+
+// indexJSON is the file format of an index definition.
+type indexJSON struct {
+	Index struct {
+		Fields []string `json:"fields"`
+	} `json:"index"`
+	DDoc string `json:"ddoc"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// NewIndex creates the definition of the index `name` on `fields`, stored in the design document "<name>Doc".
+//
+// Parameters:
+//   - name: The name of the index.
+//   - fields: The indexed fields, in order.
+//
+// Returns:
+//   - Index: The index definition.
+func NewIndex(name string, fields ...string) Index {
+	return Index{Name: name, DDoc: name + "Doc", Fields: fields}
+}
+
+// MarshalJSON encodes the index in the file format read by the peer.
+func (i Index) MarshalJSON() ([]byte, error) {
+	var file indexJSON
+	file.Index.Fields = i.Fields
+	file.DDoc = i.DDoc
+	file.Name = i.Name
+	file.Type = "json"
+	return json.Marshal(file)
+}
+
+// UnmarshalJSON decodes an index from the file format read by the peer.
+func (i *Index) UnmarshalJSON(data []byte) error {
+	var file indexJSON
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Type != "" && file.Type != "json" {
+		return fmt.Errorf("unsupported index type %s", file.Type)
+	}
+	*i = Index{Name: file.Name, DDoc: file.DDoc, Fields: file.Index.Fields}
+	return nil
+}
+
+// FileName returns the name of the file holding the index definition, "<name>.json".
+func (i Index) FileName() string {
+	return i.Name + ".json"
+}
+
+// ParseIndexTag returns the names of the indexes declared by the value of a `kalp` struct tag.
+//
+// Parameters:
+//   - tag: The value of the tag, e.g. "index=byOwner,index=byStatus".
+//
+// Returns:
+//   - []string: The names of the indexes.
+//   - error: An error if the tag holds anything but index declarations.
+func ParseIndexTag(tag string) ([]string, error) {
+	var names []string
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		name, ok := cutPrefix(option, "index=")
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q in %s tag", option, TagName)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("index name must not be empty in %s tag", TagName)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// JSONFieldName returns the name of a struct field in the JSON encoding, or "" if the field is not encoded.
+//
+// Parameters:
+//   - fieldName: The name of the Go field.
+//   - jsonTag: The value of the `json` tag of the field.
+//
+// Returns:
+//   - string: The JSON name of the field.
+func JSONFieldName(fieldName string, jsonTag string) string {
+	name := strings.Split(jsonTag, ",")[0]
+	if name == "-" && !strings.Contains(jsonTag, ",") {
+		return ""
+	}
+	if name == "" {
+		return fieldName
+	}
+	return name
+}
+
+// IndexBuilder collects the index declarations of struct fields into index definitions.
+type IndexBuilder struct {
+	fields map[string][]string
+	owners map[string]string
+}
+
+// NewIndexBuilder creates an empty IndexBuilder.
+func NewIndexBuilder() *IndexBuilder {
+	return &IndexBuilder{fields: map[string][]string{}, owners: map[string]string{}}
+}
+
+// AddField adds a field to the indexes declared by its `kalp` tag.
+//
+// Parameters:
+//   - typeName: The name of the struct type declaring the field. An index can only be declared by one type.
+//   - fieldName: The name of the Go field.
+//   - jsonTag: The value of the `json` tag of the field.
+//   - kalpTag: The value of the `kalp` tag of the field.
+//
+// Returns:
+//   - error: An error if the tag is invalid, the field is not encoded or the index is declared by another type.
+func (b *IndexBuilder) AddField(typeName string, fieldName string, jsonTag string, kalpTag string) error {
+	names, err := ParseIndexTag(kalpTag)
+	if err != nil {
+		return fmt.Errorf("field %s.%s: %v", typeName, fieldName, err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	field := JSONFieldName(fieldName, jsonTag)
+	if field == "" {
+		return fmt.Errorf("field %s.%s is indexed but not encoded in JSON", typeName, fieldName)
+	}
+	for _, name := range names {
+		if owner, ok := b.owners[name]; ok && owner != typeName {
+			return fmt.Errorf("index %s is declared by both %s and %s", name, owner, typeName)
+		}
+		if slices.Contains(b.fields[name], field) {
+			return fmt.Errorf("field %s appears twice in index %s", field, name)
+		}
+		b.owners[name] = typeName
+		b.fields[name] = append(b.fields[name], field)
+	}
+	return nil
+}
+
+// Indexes returns the collected index definitions, sorted by name.
+func (b *IndexBuilder) Indexes() []Index {
+	names := make([]string, 0, len(b.fields))
+	for name := range b.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indexes := make([]Index, 0, len(names))
+	for _, name := range names {
+		indexes = append(indexes, NewIndex(name, b.fields[name]...))
+	}
+	return indexes
+}
+
+// IndexesOf returns the CouchDB indexes declared by the `kalp` tags of the fields of a struct.
+//
+// Parameters:
+//   - v: A struct value or a pointer to one.
+//
+// Returns:
+//   - []Index: The index definitions, sorted by name.
+//   - error: An error if v is not a struct or a tag is invalid.
+func IndexesOf(v interface{}) ([]Index, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %T", v)
+	}
+
+	builder := NewIndexBuilder()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if err := builder.AddField(t.Name(), field.Name, field.Tag.Get("json"), field.Tag.Get(TagName)); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Indexes(), nil
+}
+
+// UseIndexDefinition tells CouchDB to use the given index. Build checks that the query can be served by it: every
+// indexed field must be constrained by the selector, and every sort field must be indexed.
+//
+// Parameters:
+//   - index: The index definition.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) UseIndexDefinition(index Index) *Query {
+	if len(index.Fields) == 0 {
+		return q.fail(fmt.Errorf("index %s has no fields", index.Name))
+	}
+	q.UseIndex(index.DDoc, index.Name)
+	q.index = &index
+	return q
+}
+
+// checkIndex checks that the index given to UseIndexDefinition, if any, can serve the query.
+func (q *Query) checkIndex() error {
+	if q.index == nil {
+		return nil
+	}
+
+	constrained := selectorFields(q.selector)
+	for _, field := range q.index.Fields {
+		if !constrained[field] {
+			return fmt.Errorf("index %s cannot serve the query: field %s is not in the selector", q.index.Name, field)
+		}
+	}
+	for _, sortField := range q.sort {
+		for field := range sortField {
+			if !slices.Contains(q.index.Fields, field) {
+				return fmt.Errorf("index %s cannot serve the query: sort field %s is not indexed", q.index.Name, field)
+			}
+		}
+	}
+	return nil
+}
+
+// selectorFields returns the fields constrained by the selector, including those of the selectors of a top-level
+// $and.
+func selectorFields(selector Selector) map[string]bool {
+	fields := map[string]bool{}
+	for field, condition := range selector {
+		if field != "$and" {
+			if !strings.HasPrefix(field, "$") {
+				fields[field] = true
+			}
+			continue
+		}
+		if selectors, ok := condition.([]Selector); ok {
+			for _, s := range selectors {
+				for f := range selectorFields(s) {
+					fields[f] = true
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// cutPrefix returns s without the prefix and true, or s and false if it does not start with the prefix.
+func cutPrefix(s string, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+type indexedAsset struct {
+	ID      string   `json:"id" kalp:"index=byIdDocType"`
+	DocType string   `json:"docType" kalp:"index=byIdDocType,index=byOwner"`
+	Owner   string   `json:"owner" kalp:"index=byOwner"`
+	Amount  int      `kalp:"index=byAmount"`
+	Tags    []string `json:"tags"`
+}
+
+func TestIndexesOf(t *testing.T) {
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		indexes, err := IndexesOf(&indexedAsset{})
+		require.NoError(t, err)
+		require.Equal(t, []Index{
+			{Name: "byAmount", DDoc: "byAmountDoc", Fields: []string{"Amount"}},
+			{Name: "byIdDocType", DDoc: "byIdDocTypeDoc", Fields: []string{"id", "docType"}},
+			{Name: "byOwner", DDoc: "byOwnerDoc", Fields: []string{"docType", "owner"}},
+		}, indexes)
+
+		data, err := json.Marshal(indexes[2])
+		require.NoError(t, err)
+		require.Equal(t, `{"index":{"fields":["docType","owner"]},"ddoc":"byOwnerDoc","name":"byOwner","type":"json"}`, string(data))
+
+		var decoded Index
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, indexes[2], decoded)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := IndexesOf("asset")
+		require.EqualError(t, err, "expected a struct, got string")
+
+		_, err = IndexesOf(struct {
+			ID string `kalp:"unique"`
+		}{})
+		require.EqualError(t, err, `field .ID: unknown option "unique" in kalp tag`)
+
+		_, err = IndexesOf(struct {
+			ID string `json:"-" kalp:"index=byId"`
+		}{})
+		require.EqualError(t, err, "field .ID is indexed but not encoded in JSON")
+
+		builder := NewIndexBuilder()
+		require.NoError(t, builder.AddField("NIU", "Owner", "owner", "index=byOwner"))
+		require.EqualError(t, builder.AddField("Payment", "Payer", "payer", "index=byOwner"), "index byOwner is declared by both NIU and Payment")
+		require.EqualError(t, builder.AddField("NIU", "ID", "id", "index="), "field NIU.ID: index name must not be empty in kalp tag")
+		require.EqualError(t, builder.AddField("NIU", "Holder", "owner", "index=byOwner"), "field owner appears twice in index byOwner")
+	})
+}
+
+func TestUseIndexDefinition(t *testing.T) {
+	index := NewIndex("byOwner", "docType", "owner")
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		q, err := New(Selector{"docType": "NIU"}).UseIndexDefinition(index).Where("owner", "alice").Sort("owner", Asc).Build()
+		require.NoError(t, err)
+		require.Equal(t, `{"selector":{"docType":"NIU","owner":"alice"},"sort":[{"owner":"asc"}],"use_index":["byOwnerDoc","byOwner"]}`, q)
+
+		_, err = New(And(Selector{"docType": "NIU"}, Selector{"owner": Ne("bob")})).UseIndexDefinition(index).Build()
+		require.NoError(t, err)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := New(Selector{"docType": "NIU"}).UseIndexDefinition(index).Build()
+		require.EqualError(t, err, "index byOwner cannot serve the query: field owner is not in the selector")
+
+		_, err = New(Selector{"docType": "NIU", "owner": "alice"}).Sort("amount", Desc).UseIndexDefinition(index).Build()
+		require.EqualError(t, err, "index byOwner cannot serve the query: sort field amount is not indexed")
+
+		_, err = New(nil).UseIndexDefinition(Index{Name: "empty"}).Build()
+		require.EqualError(t, err, "index empty has no fields")
+	})
+}
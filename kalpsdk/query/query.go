@@ -0,0 +1,212 @@
+// Package query builds CouchDB Mango queries for GetQueryResult and GetQueryResultWithPagination.
+//
+// Values are encoded with encoding/json, so ids and other user input containing quotes or backslashes cannot
+// break out of the selector:
+//
+//	q, err := query.New(query.Selector{"docType": "NIU", "id": id}).
+//		Sort("id", query.Asc).
+//		Limit(10).
+//		Build()
+package query
+
+import (
+	//Standard Libs
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Direction is the direction of a sort field.
+type Direction string
+
+const (
+	Asc  Direction = "asc"  // Ascending order.
+	Desc Direction = "desc" // Descending order.
+)
+
+// Query is a Mango query under construction. The methods of a Query modify and return it, so that calls can be
+// chained.
+type Query struct {
+	selector Selector
+	sort     []map[string]Direction
+	fields   []string
+	limit    *int
+	skip     *int
+	useIndex []string
+	index    *Index
+	err      error
+}
+
+// New creates a query matching the documents selected by `selector`. A nil selector matches every document. The
+// selector is copied, so that Where does not modify it.
+//
+// Parameters:
+//   - selector: The selector of the query.
+//
+// Returns:
+//   - *Query: The query.
+func New(selector Selector) *Query {
+	copied := Selector{}
+	for field, condition := range selector {
+		copied[field] = condition
+	}
+	return &Query{selector: copied}
+}
+
+// Where adds a condition on `field` to the selector of the query. The condition is either a value, matched with
+// $eq, or an operator such as Gt or In. A field given twice keeps the last condition.
+//
+// Parameters:
+//   - field: The field, with dots separating the names of nested fields.
+//   - condition: The value or operator the field must satisfy.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) Where(field string, condition interface{}) *Query {
+	if field == "" {
+		return q.fail(fmt.Errorf("field name must not be empty"))
+	}
+	q.selector[field] = condition
+	return q
+}
+
+// Sort appends a sort field to the query. CouchDB requires an index covering the sort fields, and every field
+// to be sorted in the same direction.
+//
+// Parameters:
+//   - field: The field to sort by.
+//   - direction: Asc or Desc.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) Sort(field string, direction Direction) *Query {
+	if field == "" {
+		return q.fail(fmt.Errorf("sort field name must not be empty"))
+	}
+	if direction != Asc && direction != Desc {
+		return q.fail(fmt.Errorf("invalid sort direction %q for field %s", direction, field))
+	}
+	q.sort = append(q.sort, map[string]Direction{field: direction})
+	return q
+}
+
+// Fields restricts the fields returned for every document. Records read through a repository need every field
+// they decode.
+//
+// Parameters:
+//   - fields: The fields to return.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) Fields(fields ...string) *Query {
+	for _, field := range fields {
+		if field == "" {
+			return q.fail(fmt.Errorf("field name must not be empty"))
+		}
+	}
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Limit sets the maximum number of documents returned. It is ignored by paginated queries, which use the page
+// size instead.
+//
+// Parameters:
+//   - limit: The maximum number of documents.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) Limit(limit int) *Query {
+	if limit < 0 {
+		return q.fail(fmt.Errorf("limit must not be negative"))
+	}
+	q.limit = &limit
+	return q
+}
+
+// Skip sets the number of matching documents skipped before the first one returned.
+//
+// Parameters:
+//   - skip: The number of documents to skip.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) Skip(skip int) *Query {
+	if skip < 0 {
+		return q.fail(fmt.Errorf("skip must not be negative"))
+	}
+	q.skip = &skip
+	return q
+}
+
+// UseIndex tells CouchDB which index to use, given by its design document and optionally its name, as declared
+// in the META-INF/statedb/couchdb/indexes folder of the chaincode.
+//
+// Parameters:
+//   - designDoc: The design document of the index, e.g. "indexOwnerDoc".
+//   - name: The name of the index, e.g. "indexOwner". Optional.
+//
+// Returns:
+//   - *Query: The query.
+func (q *Query) UseIndex(designDoc string, name ...string) *Query {
+	if designDoc == "" {
+		return q.fail(fmt.Errorf("index design document must not be empty"))
+	}
+	if len(name) > 1 {
+		return q.fail(fmt.Errorf("an index is given by its design document and at most one name"))
+	}
+	q.useIndex = append([]string{strings.TrimPrefix(designDoc, "_design/")}, name...)
+	q.index = nil
+	return q
+}
+
+// Build returns the query as Mango JSON.
+//
+// Returns:
+//   - string: The query, ready to be passed to GetQueryResult.
+//   - error: The first invalid argument given to the query, or an error if a value cannot be encoded as JSON.
+func (q *Query) Build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	if err := q.checkIndex(); err != nil {
+		return "", err
+	}
+
+	mango := map[string]interface{}{"selector": q.selector}
+	if len(q.sort) > 0 {
+		mango["sort"] = q.sort
+	}
+	if len(q.fields) > 0 {
+		mango["fields"] = q.fields
+	}
+	if q.limit != nil {
+		mango["limit"] = *q.limit
+	}
+	if q.skip != nil {
+		mango["skip"] = *q.skip
+	}
+	switch len(q.useIndex) {
+	case 1:
+		mango["use_index"] = q.useIndex[0]
+	case 2:
+		mango["use_index"] = q.useIndex
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(mango); err != nil {
+		return "", fmt.Errorf("failed to encode query: %v", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// fail records the first invalid argument, returned by Build.
+func (q *Query) fail(err error) *Query {
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
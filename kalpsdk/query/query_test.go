@@ -0,0 +1,71 @@
+package query
+
+import (
+	//Standard Libs
+	"math"
+	"testing"
+
+	//Third party Libs
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *Query
+		expected string
+	}{
+		{"Check empty selector", New(nil), `{"selector":{}}`},
+		{"Check escaped values", New(Selector{"id": `a1", "docType": {"$ne": null}`}), `{"selector":{"id":"a1\", \"docType\": {\"$ne\": null}"}}`},
+		{"Check HTML characters are kept", New(Selector{"name": "<R&D>"}), `{"selector":{"name":"<R&D>"}}`},
+		{"Check operators", New(Selector{"amount": Gte(5)}).Where("status", In("COMPLETED", "INPROGRESS")).Where("account", ElemMatch(Eq("alice"))),
+			`{"selector":{"account":{"$elemMatch":{"$eq":"alice"}},"amount":{"$gte":5},"status":{"$in":["COMPLETED","INPROGRESS"]}}}`},
+		{"Check combinations", New(Or(Selector{"amount": Lt(5)}, Not(Selector{"status": Exists(true)}))),
+			`{"selector":{"$or":[{"amount":{"$lt":5}},{"$not":{"status":{"$exists":true}}}]}}`},
+		{"Check empty operator arguments", New(And()).Where("tags", All()), `{"selector":{"$and":[],"tags":{"$all":[]}}}`},
+		{"Check sort, fields, limit, skip and index", New(Selector{"docType": "NIU"}).Sort("docType", Asc).Sort("amount", Asc).Fields("id", "amount").Limit(10).Skip(20).UseIndex("_design/indexAmountDoc", "indexAmount"),
+			`{"fields":["id","amount"],"limit":10,"selector":{"docType":"NIU"},"skip":20,"sort":[{"docType":"asc"},{"amount":"asc"}],"use_index":["indexAmountDoc","indexAmount"]}`},
+		{"Check index without name", New(nil).UseIndex("indexAmountDoc"), `{"selector":{},"use_index":"indexAmountDoc"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.query.Build()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+
+	// Check that Where does not modify the selector given to New
+	t.Run("Check selector is copied", func(t *testing.T) {
+		selector := Selector{"docType": "NIU"}
+		New(selector).Where("id", "a1")
+		require.Equal(t, Selector{"docType": "NIU"}, selector)
+	})
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *Query
+		expected string
+	}{
+		{"Check empty field", New(nil).Where("", 1), "field name must not be empty"},
+		{"Check empty sort field", New(nil).Sort("", Asc), "sort field name must not be empty"},
+		{"Check sort direction", New(nil).Sort("amount", "up"), `invalid sort direction "up" for field amount`},
+		{"Check empty projected field", New(nil).Fields("id", ""), "field name must not be empty"},
+		{"Check negative limit", New(nil).Limit(-1), "limit must not be negative"},
+		{"Check negative skip", New(nil).Skip(-1), "skip must not be negative"},
+		{"Check empty index", New(nil).UseIndex(""), "index design document must not be empty"},
+		{"Check index names", New(nil).UseIndex("doc", "a", "b"), "an index is given by its design document and at most one name"},
+		{"Check first error is kept", New(nil).Limit(-1).Skip(-1), "limit must not be negative"},
+		{"Check unencodable value", New(Selector{"amount": math.NaN()}), "failed to encode query: json: unsupported value: NaN"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.query.Build()
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package query
+
+// Selector maps fields, with dots separating the names of nested fields, to the value or operator they must
+// satisfy. It may also hold the combination operators built by And, Or, Nor and Not.
+type Selector map[string]interface{}
+
+// Operator is a Mango condition operator, such as {"$gt": 5}.
+type Operator map[string]interface{}
+
+// Eq matches values equal to `value`.
+func Eq(value interface{}) Operator {
+	return Operator{"$eq": value}
+}
+
+// Ne matches values not equal to `value`.
+func Ne(value interface{}) Operator {
+	return Operator{"$ne": value}
+}
+
+// Gt matches values greater than `value`.
+func Gt(value interface{}) Operator {
+	return Operator{"$gt": value}
+}
+
+// Gte matches values greater than or equal to `value`.
+func Gte(value interface{}) Operator {
+	return Operator{"$gte": value}
+}
+
+// Lt matches values less than `value`.
+func Lt(value interface{}) Operator {
+	return Operator{"$lt": value}
+}
+
+// Lte matches values less than or equal to `value`.
+func Lte(value interface{}) Operator {
+	return Operator{"$lte": value}
+}
+
+// In matches values equal to one of `values`.
+func In(values ...interface{}) Operator {
+	return Operator{"$in": nonNil(values)}
+}
+
+// Nin matches values equal to none of `values`.
+func Nin(values ...interface{}) Operator {
+	return Operator{"$nin": nonNil(values)}
+}
+
+// Exists matches documents that have the field if `exists` is true, or lack it otherwise.
+func Exists(exists bool) Operator {
+	return Operator{"$exists": exists}
+}
+
+// Regex matches string values matching the Erlang regular expression `pattern`.
+func Regex(pattern string) Operator {
+	return Operator{"$regex": pattern}
+}
+
+// Size matches arrays of length `size`.
+func Size(size int) Operator {
+	return Operator{"$size": size}
+}
+
+// All matches arrays containing every one of `values`.
+func All(values ...interface{}) Operator {
+	return Operator{"$all": nonNil(values)}
+}
+
+// ElemMatch matches arrays with at least one element satisfying `condition`, either an Operator for arrays of
+// values or a Selector for arrays of objects.
+func ElemMatch(condition interface{}) Operator {
+	return Operator{"$elemMatch": condition}
+}
+
+// And matches documents satisfying every one of `selectors`.
+func And(selectors ...Selector) Selector {
+	return Selector{"$and": nonNilSelectors(selectors)}
+}
+
+// Or matches documents satisfying at least one of `selectors`.
+func Or(selectors ...Selector) Selector {
+	return Selector{"$or": nonNilSelectors(selectors)}
+}
+
+// Nor matches documents satisfying none of `selectors`.
+func Nor(selectors ...Selector) Selector {
+	return Selector{"$nor": nonNilSelectors(selectors)}
+}
+
+// Not matches documents not satisfying `selector`.
+func Not(selector Selector) Selector {
+	if selector == nil {
+		selector = Selector{}
+	}
+	return Selector{"$not": selector}
+}
+
+// nonNil returns an empty slice for nil, which would be encoded as null.
+func nonNil(values []interface{}) []interface{} {
+	if values == nil {
+		return []interface{}{}
+	}
+	return values
+}
+
+// nonNilSelectors returns an empty slice for nil, which would be encoded as null.
+func nonNilSelectors(selectors []Selector) []Selector {
+	if selectors == nil {
+		return []Selector{}
+	}
+	return selectors
+}
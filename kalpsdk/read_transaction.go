@@ -2,10 +2,7 @@ package kalpsdk
 
 import (
 	//Standard Libs
-	"encoding/base64"
 	"fmt"
-	"strconv"
-	"strings"
 
 	//Third party Libs
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -25,8 +22,14 @@ func (ctx *TransactionContext) GetChannelName() (string, error) {
 	return channelID, nil
 }
 
-// GetKYC checks if a user has completed KYC on our network by invoking the KycExists function
-// on the kyc chaincode for the given user ID in the universalkyc channel.
+// GetKYC checks if a user has completed KYC on our network by looking up the user with the
+// KYCProvider of the transaction. A user has completed KYC if the provider reports at least
+// KYCLevelBasic and the KYC is neither revoked nor expired. By default the exists function of
+// the KYC chaincode described by the KYCConfig of the transaction is invoked.
+//
+// The result is remembered for the rest of the transaction, so checking the same user again,
+// e.g. by calling PutStateWithKYC for several keys, does not invoke the KYC chaincode again.
+// Failed checks are not remembered.
 //
 // Parameters:
 //   - userId: The ID of the user to check for KYC completion.
@@ -35,59 +38,72 @@ func (ctx *TransactionContext) GetChannelName() (string, error) {
 //   - bool: A boolean value indicating whether the user has completed KYC.
 //   - error: An error if the operation fails.
 func (ctx *TransactionContext) GetKYC(userId string) (bool, error) {
-	// Set the function name and chaincode name for the cross-chaincode invocation.
-	crossCCFunc := "KycExists"
-	crossCCName := "kyc"
-
-	// Call the GetChannelName function to obtain the channel name.
-	channelName, err := ctx.GetChannelName()
+	status, err := ctx.GetKYCStatus(userId)
 	if err != nil {
-		return false, fmt.Errorf("failed to get channel name: %s", err.Error())
+		return false, err
 	}
-
-	// Set the parameters for the KycExists function.
-	params := []string{crossCCFunc, userId}
-
-	// Convert the parameters to byte arrays.
-	queryArgs := make([][]byte, len(params))
-	for i, arg := range params {
-		queryArgs[i] = []byte(arg)
+	if status.Level == KYCLevelNone || status.Revoked {
+		return false, nil
 	}
+	expired, err := ctx.isKYCExpired(status)
+	if err != nil {
+		return false, err
+	}
+	return !expired, nil
+}
 
-	// Invoke the KycExists function on the kyc chaincode in the channel.
-	response := ctx.GetStub().InvokeChaincode(crossCCName, queryArgs, channelName)
-
-	// Check if the response status is not 200 OK.
-	if response.Status != 200 {
-		// Return an error with a descriptive message.
-		return false, fmt.Errorf("failed to query kyc chaincode for user %s. Got status %d and error message: %s", userId, response.Status, response.Payload)
+// GetKYCBatch checks the KYC status of several users, invoking the KYC chaincode once for
+// each user that has not been checked yet in this transaction.
+//
+// Parameters:
+//   - userIDs: The IDs of the users to check for KYC completion. Duplicates are checked once.
+//
+// Returns:
+//   - map[string]bool: Whether each user has completed KYC, keyed by user ID.
+//   - error: An error if the check fails for any of the users.
+func (ctx *TransactionContext) GetKYCBatch(userIDs []string) (map[string]bool, error) {
+	kycChecks := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := kycChecks[userID]; ok {
+			continue
+		}
+		kycCheck, err := ctx.GetKYC(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to perform KYC check for user %s: %v", userID, err)
+		}
+		kycChecks[userID] = kycCheck
 	}
+	return kycChecks, nil
+}
 
-	// Convert the response payload to a boolean and return it.
-	return strconv.ParseBool(string(response.Payload))
+// getKYCCache returns the KYC statuses looked up in the current transaction, starting an empty
+// cache whenever the stub moves on to another transaction.
+func (ctx *TransactionContext) getKYCCache() map[string]KYCStatus {
+	txID := ctx.GetStub().GetTxID()
+	if ctx.kycCache == nil || ctx.kycCacheTxID != txID {
+		ctx.kycCache = make(map[string]KYCStatus)
+		ctx.kycCacheTxID = txID
+	}
+	return ctx.kycCache
 }
 
 // GetUserID retrieves the name of the minter from the CA certificate embedded in the client identity.
-// It returns the user ID extracted from the client identity and an error if there was a failure in
-// reading or extracting the user ID.
+// It returns the common name (CN) of the certificate subject, parsed from the client ID, and an error if
+// there was a failure in reading or extracting the user ID. Escaped characters in the subject are handled
+// and the CN does not need to be the first attribute.
 //
 // Returns:
 //   - string: The user ID extracted from the client identity.
-//   - error: An error if there was a failure in reading or extracting the user ID.
+//   - error: An *IdentityError if there was a failure in reading or extracting the user ID.
 func (ctx *TransactionContext) GetUserID() (string, error) {
-	b64ID, err := ctx.GetClientIdentity().GetID()
+	subject, _, err := ctx.getClientIDNames()
 	if err != nil {
-		return "", fmt.Errorf("failed to read clientID: %v", err)
+		return "", err
 	}
-
-	decodeID, err := base64.StdEncoding.DecodeString(b64ID)
-	if err != nil {
-		return "", fmt.Errorf("failed to base64 decode clientID: %v", err)
+	if subject.CommonName == "" {
+		return "", &IdentityError{Op: "failed to parse clientID", Err: ErrMissingCommonName}
 	}
-
-	completeID := string(decodeID)
-	userID := completeID[(strings.Index(completeID, "x509::CN=") + 9):strings.Index(completeID, ",")]
-	return userID, nil
+	return subject.CommonName, nil
 }
 
 // GetState retrieves the value of the specified `key` from the ledger.
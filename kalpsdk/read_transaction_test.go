@@ -83,7 +83,7 @@ func TestGetUserID(t *testing.T) {
 		clientIdentity: mockClientIdentity,
 	}
 
-	expectedId := "eDUwOTo6Q049VGVzdE93bmVyLDEyMw=="
+	expectedId := "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ=="
 	decodeId := "TestOwner"
 
 	// Check for success response
@@ -115,10 +115,13 @@ func TestGetKYC(t *testing.T) {
 	ctx := &TransactionContext{
 		stub: mockStub,
 	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Twice()
 
 	// Check for success response
 	t.Run("Check for Success response", func(t *testing.T) {
 		expectedResponse := peer.Response{Status: shim.OK, Payload: []byte("true")}
+		mockStub.On("GetTxID").Return("tx1").Twice()
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestUser")}, "universalkyc").Return(expectedResponse).Once()
 
 		result, err := ctx.GetKYC("TestUser")
@@ -132,6 +135,7 @@ func TestGetKYC(t *testing.T) {
 	t.Run("Check for Failure response", func(t *testing.T) {
 		userID := "TestUser"
 		expectedResponse := peer.Response{Status: shim.ERROR, Payload: []byte("failed to query kyc chaincode")}
+		mockStub.On("GetTxID").Return("tx2").Twice()
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte(userID)}, "universalkyc").Return(expectedResponse)
 
 		_, err := ctx.GetKYC(userID)
@@ -140,6 +144,68 @@ func TestGetKYC(t *testing.T) {
 	})
 }
 
+func TestGetKYCCache(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Twice()
+
+	// Check that the KYC chaincode is invoked once per user and transaction
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx1").Times(4)
+		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestUser")}, "universalkyc").Return(peer.Response{Status: shim.OK, Payload: []byte("true")}).Once()
+
+		for i := 0; i < 3; i++ {
+			result, err := ctx.GetKYC("TestUser")
+			require.NoError(t, err)
+			require.True(t, result)
+		}
+		mockStub.AssertNumberOfCalls(t, "InvokeChaincode", 1)
+	})
+
+	// Check that the cache does not outlive the transaction
+	t.Run("Check for new transaction", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx2").Twice()
+		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestUser")}, "universalkyc").Return(peer.Response{Status: shim.OK, Payload: []byte("false")}).Once()
+
+		result, err := ctx.GetKYC("TestUser")
+		require.NoError(t, err)
+		require.False(t, result)
+		mockStub.AssertNumberOfCalls(t, "InvokeChaincode", 2)
+	})
+}
+
+func TestGetKYCBatch(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	ctx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Once()
+	mockStub.On("GetTxID").Return("tx1")
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("alice")}, "universalkyc").Return(peer.Response{Status: shim.OK, Payload: []byte("true")}).Once()
+		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("bob")}, "universalkyc").Return(peer.Response{Status: shim.OK, Payload: []byte("false")}).Once()
+
+		result, err := ctx.GetKYCBatch([]string{"alice", "bob", "alice"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"alice": true, "bob": false}, result)
+		mockStub.AssertNumberOfCalls(t, "InvokeChaincode", 2)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("carol")}, "universalkyc").Return(peer.Response{Status: shim.ERROR, Payload: []byte("kyc unavailable")}).Once()
+
+		_, err := ctx.GetKYCBatch([]string{"alice", "carol"})
+		require.EqualError(t, err, "failed to perform KYC check for user carol: failed to query kyc chaincode for user carol. Got status 500 and error message: kyc unavailable")
+	})
+}
+
 func TestGetChannelID(t *testing.T) {
 	mockStub := new(mocks.ChaincodeStubInterface)
 	ctx := &TransactionContext{
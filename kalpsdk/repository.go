@@ -0,0 +1,352 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
+)
+
+// DocTypeField is the JSON field holding the document type of the records listed by Repository.List.
+const DocTypeField = "docType"
+
+var (
+	// ErrNotFound is wrapped by the errors returned when a record does not exist.
+	ErrNotFound = errors.New("does not exist")
+	// ErrAlreadyExists is wrapped by the errors returned when a record already exists.
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// StateError is returned by Repository when a record is missing or already exists. It wraps ErrNotFound or
+// ErrAlreadyExists, so callers can test for them with errors.Is.
+type StateError struct {
+	DocType string // The document type of the repository.
+	Key     string // The key of the record.
+	Err     error  // ErrNotFound or ErrAlreadyExists.
+}
+
+// Error returns e.g. "NIU niu1 does not exist".
+func (e *StateError) Error() string {
+	docType := e.DocType
+	if docType == "" {
+		docType = "key"
+	}
+	return fmt.Sprintf("%s %s %v", docType, e.Key, e.Err)
+}
+
+// Unwrap returns the wrapped sentinel error.
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
+
+// Repository reads and writes records of type T, stored as JSON in the world state, so that contract functions
+// do not have to repeat the read, unmarshal, marshal and write steps. Writes require the user to have completed
+// KYC unless the repository is created WithoutKYC.
+//
+// Like GetState, the reads of a repository only see the committed state, not the writes of the current transaction.
+// Its writes do see the records the repository wrote earlier in the transaction, so that writing a record twice
+// replaces the index entries of the first write.
+type Repository[T any] struct {
+	ctx      TransactionContextInterface
+	docType  string
+	kycLevel KYCLevel
+	indexes  []Index[T]
+	written  map[string][]byte // Records written in this transaction by the repository and its copies; nil if deleted.
+}
+
+// NewRepository creates a repository of records of type T with the given document type. The records must hold the
+// document type in their DocTypeField for List to find them.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - docType: The document type of the records, e.g. "NIU".
+//
+// Returns:
+//   - *Repository[T]: The repository, writing with KYC of at least KYCLevelBasic.
+func NewRepository[T any](ctx TransactionContextInterface, docType string) *Repository[T] {
+	return &Repository[T]{ctx: ctx, docType: docType, kycLevel: KYCLevelBasic, written: map[string][]byte{}}
+}
+
+// WithKYCLevel returns a copy of the repository whose writes require the user to have an active KYC of at least
+// `minLevel`. KYCLevelNone writes without KYC.
+//
+// Parameters:
+//   - minLevel: The minimum KYC level required for writes.
+//
+// Returns:
+//   - *Repository[T]: The repository copy.
+func (r *Repository[T]) WithKYCLevel(minLevel KYCLevel) *Repository[T] {
+	copied := *r
+	copied.kycLevel = minLevel
+	return &copied
+}
+
+// WithoutKYC returns a copy of the repository whose writes do not require KYC.
+//
+// Returns:
+//   - *Repository[T]: The repository copy.
+func (r *Repository[T]) WithoutKYC() *Repository[T] {
+	return r.WithKYCLevel(KYCLevelNone)
+}
+
+// DocType returns the document type of the repository.
+func (r *Repository[T]) DocType() string {
+	return r.docType
+}
+
+// Get reads the record stored under `key`.
+//
+// Parameters:
+//   - key: The key of the record.
+//
+// Returns:
+//   - *T: The record, or nil if it does not exist.
+//   - error: An error if the state could not be read or decoded.
+func (r *Repository[T]) Get(key string) (*T, error) {
+	data, err := r.read(key)
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(key, data)
+}
+
+// MustGet reads the record stored under `key`, which must exist.
+//
+// Parameters:
+//   - key: The key of the record.
+//
+// Returns:
+//   - *T: The record.
+//   - error: A *StateError wrapping ErrNotFound if the record does not exist, or an error if the state could not be
+//     read or decoded.
+func (r *Repository[T]) MustGet(key string) (*T, error) {
+	record, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, r.stateError(key, ErrNotFound)
+	}
+	return record, nil
+}
+
+// Exists reports whether a record is stored under `key`.
+//
+// Parameters:
+//   - key: The key of the record.
+//
+// Returns:
+//   - bool: True if the record exists.
+//   - error: An error if the state could not be read.
+func (r *Repository[T]) Exists(key string) (bool, error) {
+	data, err := r.read(key)
+	if err != nil {
+		return false, err
+	}
+	return data != nil, nil
+}
+
+// Create stores a new record under `key`.
+//
+// Parameters:
+//   - key: The key of the record.
+//   - record: The record to store.
+//
+// Returns:
+//   - error: A *StateError wrapping ErrAlreadyExists if a record is already stored under `key`, or an error if the
+//     record could not be written.
+func (r *Repository[T]) Create(key string, record *T) error {
+	previous, err := r.previous(key)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		return r.stateError(key, ErrAlreadyExists)
+	}
+	return r.put(key, nil, record)
+}
+
+// Update replaces the record stored under `key`.
+//
+// Parameters:
+//   - key: The key of the record.
+//   - record: The new record.
+//
+// Returns:
+//   - error: A *StateError wrapping ErrNotFound if no record is stored under `key`, or an error if the record could
+//     not be written.
+func (r *Repository[T]) Update(key string, record *T) error {
+	previous, err := r.previous(key)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return r.stateError(key, ErrNotFound)
+	}
+	return r.put(key, previous, record)
+}
+
+// Upsert stores the record under `key`, whether or not a record is already stored under it.
+//
+// Parameters:
+//   - key: The key of the record.
+//   - record: The record to store.
+//
+// Returns:
+//   - error: An error if the record could not be written.
+func (r *Repository[T]) Upsert(key string, record *T) error {
+	previous, err := r.previous(key)
+	if err != nil {
+		return err
+	}
+	return r.put(key, previous, record)
+}
+
+// Delete deletes the record stored under `key`.
+//
+// Parameters:
+//   - key: The key of the record.
+//
+// Returns:
+//   - error: A *StateError wrapping ErrNotFound if no record is stored under `key`, or an error if the record could
+//     not be deleted.
+func (r *Repository[T]) Delete(key string) error {
+	previous, err := r.previous(key)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return r.stateError(key, ErrNotFound)
+	}
+
+	if err := r.del(key); err != nil {
+		return err
+	}
+	r.written[key] = nil
+	return r.updateIndexEntries(key, previous, nil)
+}
+
+// List returns every record of the document type of the repository, found with a rich query on DocTypeField.
+// Rich queries are only supported by CouchDB.
+//
+// Returns:
+//   - []T: The records.
+//   - error: An error if the query fails or a record could not be decoded.
+func (r *Repository[T]) List() ([]T, error) {
+	listQuery, err := r.listQuery()
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := r.ctx.GetQueryResult(listQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s records: %v", r.docType, err)
+	}
+	return Collect[T](iterator)
+}
+
+// ListPage returns a page of at most `pageSize` records of the document type of the repository, starting at
+// `bookmark`. Paginated queries are only allowed in read-only transactions.
+//
+// Parameters:
+//   - pageSize: The maximum number of records to return.
+//   - bookmark: The bookmark returned with the previous page, or empty for the first page.
+//
+// Returns:
+//   - *Page[T]: The page of records.
+//   - error: An error if the query fails or a record could not be decoded.
+func (r *Repository[T]) ListPage(pageSize int32, bookmark string) (*Page[T], error) {
+	listQuery, err := r.listQuery()
+	if err != nil {
+		return nil, err
+	}
+	iterator, metadata, err := r.ctx.GetQueryResultWithPagination(listQuery, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s records: %v", r.docType, err)
+	}
+	return NewPage[T](iterator, metadata)
+}
+
+// read returns the raw value stored under `key`, nil if it does not exist.
+func (r *Repository[T]) read(key string) ([]byte, error) {
+	data, err := r.ctx.GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s from world state: %v", r.docType, key, err)
+	}
+	return data, nil
+}
+
+// decode unmarshals the value stored under `key`, returning nil for a nil value.
+func (r *Repository[T]) decode(key string, data []byte) (*T, error) {
+	if data == nil {
+		return nil, nil
+	}
+	record := new(T)
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s %s: %v", r.docType, key, err)
+	}
+	return record, nil
+}
+
+// previous returns the record stored under `key` before a write, nil if it does not exist. A record written earlier
+// in the transaction is returned instead of the committed one, which GetState would return. The record is only
+// decoded when the repository has indexes to update.
+func (r *Repository[T]) previous(key string) (*T, error) {
+	data, written := r.written[key]
+	if !written {
+		var err error
+		if data, err = r.read(key); err != nil {
+			return nil, err
+		}
+	}
+	if data == nil {
+		return nil, nil
+	}
+	if len(r.indexes) == 0 {
+		return new(T), nil
+	}
+	return r.decode(key, data)
+}
+
+// put marshals the record, writes it with the KYC level of the repository and updates the index entries that
+// differ from those of the previous record.
+func (r *Repository[T]) put(key string, previous *T, record *T) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %s: %v", r.docType, key, err)
+	}
+
+	if err := r.write(key, data); err != nil {
+		return err
+	}
+	r.written[key] = data
+	return r.updateIndexEntries(key, previous, record)
+}
+
+// write puts the value with the KYC level of the repository.
+func (r *Repository[T]) write(key string, data []byte) error {
+	if r.kycLevel == KYCLevelNone {
+		return r.ctx.PutStateWithoutKYC(key, data)
+	}
+	return r.ctx.PutStateWithKYCLevel(key, data, r.kycLevel)
+}
+
+// del deletes the key with the KYC level of the repository.
+func (r *Repository[T]) del(key string) error {
+	if r.kycLevel == KYCLevelNone {
+		return r.ctx.DelStateWithoutKYC(key)
+	}
+	return r.ctx.DelStateWithKYCLevel(key, r.kycLevel)
+}
+
+// listQuery returns the rich query selecting the records of the document type.
+func (r *Repository[T]) listQuery() (string, error) {
+	return query.New(query.Selector{DocTypeField: r.docType}).Build()
+}
+
+// stateError returns a *StateError for `key`.
+func (r *Repository[T]) stateError(key string, err error) error {
+	return &StateError{DocType: r.docType, Key: key, Err: err}
+}
@@ -0,0 +1,159 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"errors"
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type testAsset struct {
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	Owner   string `json:"owner"`
+}
+
+func TestRepositoryGet(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	repo := NewRepository[testAsset](&TransactionContext{stub: mockStub}, "asset")
+	mockStub.On("GetState", "a1").Return([]byte(`{"docType":"asset","id":"a1","owner":"alice"}`), nil)
+	mockStub.On("GetState", "a2").Return(nil, nil)
+	mockStub.On("GetState", "a3").Return([]byte(`not json`), nil)
+	mockStub.On("GetState", "a4").Return(nil, fmt.Errorf("ledger unavailable"))
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		asset, err := repo.Get("a1")
+		require.NoError(t, err)
+		require.Equal(t, &testAsset{DocType: "asset", ID: "a1", Owner: "alice"}, asset)
+
+		asset, err = repo.Get("a2")
+		require.NoError(t, err)
+		require.Nil(t, asset)
+
+		asset, err = repo.MustGet("a1")
+		require.NoError(t, err)
+		require.Equal(t, "alice", asset.Owner)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := repo.MustGet("a2")
+		require.EqualError(t, err, "asset a2 does not exist")
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		_, err = repo.Get("a3")
+		require.ErrorContains(t, err, "failed to unmarshal asset a3")
+
+		_, err = repo.MustGet("a4")
+		require.EqualError(t, err, "failed to read asset a4 from world state: ledger unavailable")
+	})
+}
+
+func TestRepositoryWrites(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	repo := NewRepository[testAsset](&TransactionContext{stub: mockStub}, "asset").WithoutKYC()
+	asset := &testAsset{DocType: "asset", ID: "a1", Owner: "alice"}
+	value := []byte(`{"docType":"asset","id":"a1","owner":"alice"}`)
+	mockStub.On("GetState", "a1").Return(value, nil)
+	mockStub.On("GetState", "a2").Return(nil, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("PutState", "a2", value).Return(nil).Twice()
+		mockStub.On("PutState", "a1", value).Return(nil).Twice()
+		mockStub.On("DelState", "a1").Return(nil).Once()
+
+		require.NoError(t, repo.Create("a2", asset))
+		require.NoError(t, repo.Upsert("a2", asset))
+		require.NoError(t, repo.Update("a1", asset))
+		require.NoError(t, repo.Upsert("a1", asset))
+		require.NoError(t, repo.Delete("a1"))
+		mockStub.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		// The writes of another transaction only see the committed state
+		repo := NewRepository[testAsset](&TransactionContext{stub: mockStub}, "asset").WithoutKYC()
+		err := repo.Create("a1", asset)
+		require.EqualError(t, err, "asset a1 already exists")
+		require.True(t, errors.Is(err, ErrAlreadyExists))
+
+		err = repo.Update("a2", asset)
+		require.EqualError(t, err, "asset a2 does not exist")
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		err = repo.Delete("a2")
+		require.True(t, errors.Is(err, ErrNotFound))
+	})
+}
+
+func TestRepositoryWritesWithKYC(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	mockClientIdentity := new(mocks.ClientIdentity)
+	kycLevel := KYCLevelBasic
+	ctx := &TransactionContext{
+		stub:           mockStub,
+		clientIdentity: mockClientIdentity,
+		kycProvider: KYCProviderFunc(func(ctx TransactionContextInterface, userID string) (KYCStatus, error) {
+			return KYCStatus{Level: kycLevel}, nil
+		}),
+	}
+	repo := NewRepository[testAsset](ctx, "asset")
+	asset := &testAsset{DocType: "asset", ID: "a1", Owner: "alice"}
+	mockClientIdentity.On("GetID").Return(testOwnerID, nil)
+	mockStub.On("GetState", "a1").Return(nil, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx1").Once()
+		mockStub.On("PutState", "a1", []byte(`{"docType":"asset","id":"a1","owner":"alice"}`)).Return(nil).Once()
+
+		require.NoError(t, repo.Create("a1", asset))
+		mockStub.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx2").Once()
+		mockStub.On("GetState", "a2").Return(nil, nil)
+
+		require.EqualError(t, repo.WithKYCLevel(KYCLevelEnhanced).Create("a2", asset), "access denied: user TestOwner has KYC level basic, but enhanced is required")
+		require.Equal(t, KYCLevelBasic, repo.kycLevel)
+	})
+}
+
+func TestRepositoryList(t *testing.T) {
+	mockStub := new(mocks.ChaincodeStubInterface)
+	repo := NewRepository[testAsset](&TransactionContext{stub: mockStub}, "asset")
+	query := `{"selector":{"docType":"asset"}}`
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		mockState := new(mocks.StateQueryIteratorInterface)
+		mockState.On("HasNext").Return(true).Once()
+		mockState.On("HasNext").Return(false).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: "a1", Value: []byte(`{"docType":"asset","id":"a1","owner":"alice"}`)}, nil).Once()
+		mockState.On("Close").Return(nil).Once()
+		mockStub.On("GetQueryResult", query).Return(mockState, nil).Once()
+
+		assets, err := repo.List()
+		require.NoError(t, err)
+		require.Equal(t, []testAsset{{DocType: "asset", ID: "a1", Owner: "alice"}}, assets)
+		mockState.AssertExpectations(t)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		mockStub.On("GetQueryResult", query).Return(nil, fmt.Errorf("rich queries are not supported")).Once()
+
+		_, err := repo.List()
+		require.EqualError(t, err, "failed to query asset records: rich queries are not supported")
+	})
+}
@@ -13,6 +13,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 )
 
+//go:generate mockery --name TransactionContextInterface
 type TransactionContextInterface interface {
 	// PutStateWithKYC puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal, only if the user has completed KYC.
@@ -21,6 +22,10 @@ type TransactionContextInterface interface {
 	// the transaction proposal and will be committed if the transaction is validated successfully.
 	PutStateWithKYC(key string, value []byte) error
 
+	// PutStateWithKYCLevel puts the specified `key` and `value` into the transaction's writeset,
+	// only if the user has an active KYC of at least `minLevel`.
+	PutStateWithKYCLevel(key string, value []byte, minLevel KYCLevel) error
+
 	// PutStateWithoutKYC puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal without requiring KYC verification.
 	// The data is not immediately written to the ledger, but instead, it becomes part of
@@ -31,16 +36,27 @@ type TransactionContextInterface interface {
 	// which enforces KYC restrictions and provides an additional layer of security.
 	PutStateWithoutKYC(key string, value []byte) error
 
-	// GetKYC checks if a user has completed KYC on our network by invoking the KycExists function on the kyc chaincode
-	// for the given user ID in the universalkyc channel.
+	// GetKYC checks if a user has completed KYC on our network by invoking the exists function of the KYC chaincode
+	// for the given user ID, as configured by the KYCConfig of the transaction.
 	GetKYC(userId string) (bool, error)
 
+	// GetKYCStatus returns the KYC status of a user as reported by the KYCProvider of the transaction,
+	// ChaincodeKYCProvider unless the contract sets another one.
+	GetKYCStatus(userId string) (KYCStatus, error)
+
+	// GetKYCBatch checks the KYC status of several users and returns it keyed by user ID. Like GetKYC, the
+	// results are remembered for the rest of the transaction.
+	GetKYCBatch(userIDs []string) (map[string]bool, error)
+
 	// PutKYC records the KYC information associated with a user.
-	// It invokes the "kyc" chaincode with the specified parameters to create a KYC record.
-	// This function should only be used by administrators to create KYC records. It invokes
-	// the "kyc" chaincode with the specified parameters using the "universalkyc" chaincode name.
+	// It invokes the create function of the KYC chaincode, as configured by the KYCConfig of the transaction.
+	// This function should only be used by administrators to create KYC records.
 	PutKYC(id string, kycId string, kycHash string) error
 
+	// GetKYCConfig returns the KYC configuration in effect for the transaction, resolved from the KYCConfig of
+	// the contract, the configuration stored at chaincode init, the KALP_KYC_* environment variables and the defaults.
+	GetKYCConfig() (KYCConfig, error)
+
 	// DelStateWithoutKYC records the specified `key` to be deleted in the writeset of
 	// the transaction proposal. The `key` and its value will be deleted from
 	// the ledger when the transaction is validated and successfully committed.
@@ -59,6 +75,10 @@ type TransactionContextInterface interface {
 	// data from the ledger, providing an additional layer of security and compliance.
 	DelStateWithKYC(key string) error
 
+	// DelStateWithKYCLevel records the specified `key` to be deleted in the writeset of the
+	// transaction proposal, only if the user has an active KYC of at least `minLevel`.
+	DelStateWithKYCLevel(key string, minLevel KYCLevel) error
+
 	// GetState returns the value of the specified `key` from the
 	// ledger. Note that GetState doesn't read data from the writeset, which
 	// has not been committed to the ledger. In other words, GetState doesn't
@@ -66,6 +86,103 @@ type TransactionContextInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetPrivateData returns the value of the specified `key` from the specified
+	// `collection`. Note that GetPrivateData doesn't read data from the
+	// private writeset, which has not been committed to the `collection`. In
+	// other words, GetPrivateData doesn't consider data modified by PutPrivateData
+	// that has not been committed.
+	GetPrivateData(collection string, key string) ([]byte, error)
+
+	// GetPrivateDataHash returns the hash of the value of the specified `key` from the specified
+	// `collection`.
+	GetPrivateDataHash(collection string, key string) ([]byte, error)
+
+	// PutPrivateDataWithKYC puts the specified `key` and `value` into the transaction's
+	// private writeset of the `collection`, only if the user has completed KYC.
+	// If the user has not completed KYC, an error is returned.
+	PutPrivateDataWithKYC(collection string, key string, value []byte) error
+
+	// PutPrivateDataWithoutKYC puts the specified `key` and `value` into the transaction's
+	// private writeset of the `collection` without requiring KYC verification.
+	// It is recommended to use PutPrivateDataWithKYC instead.
+	PutPrivateDataWithoutKYC(collection string, key string, value []byte) error
+
+	// DelPrivateDataWithKYC records the specified `key` to be deleted in the private writeset
+	// of the `collection`, only if the user has completed KYC.
+	DelPrivateDataWithKYC(collection string, key string) error
+
+	// DelPrivateDataWithoutKYC records the specified `key` to be deleted in the private writeset
+	// of the `collection` without requiring KYC verification.
+	// It is recommended to use DelPrivateDataWithKYC instead.
+	DelPrivateDataWithoutKYC(collection string, key string) error
+
+	// PurgePrivateDataWithKYC records the specified `key` to be purged, together with its
+	// history, in the private writeset of the `collection`, only if the user has completed KYC.
+	PurgePrivateDataWithKYC(collection string, key string) error
+
+	// PurgePrivateDataWithoutKYC records the specified `key` to be purged, together with its
+	// history, in the private writeset of the `collection` without requiring KYC verification.
+	// It is recommended to use PurgePrivateDataWithKYC instead.
+	PurgePrivateDataWithoutKYC(collection string, key string) error
+
+	// GetPrivateDataByRange returns a range iterator over a set of keys in a
+	// given private collection. The iterator can be used to iterate over all keys
+	// between the startKey (inclusive) and endKey (exclusive).
+	// The keys are returned by the iterator in lexical order. Note
+	// that startKey and endKey can be empty string, which implies unbounded range
+	// query on start or end.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	GetPrivateDataByRange(collection string, startKey string, endKey string) (StateQueryIteratorInterface, error)
+
+	// GetPrivateDataByPartialCompositeKey queries the state in a given private
+	// collection based on a given partial composite key. This function returns
+	// an iterator which can be used to iterate over all composite keys whose prefix
+	// matches the given partial composite key.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	GetPrivateDataByPartialCompositeKey(collection string, objectType string, keys []string) (StateQueryIteratorInterface, error)
+
+	// GetPrivateDataQueryResult performs a "rich" query against a given private
+	// collection. It is only supported for state databases that support rich query,
+	// e.g.CouchDB. The query string is in the native syntax
+	// of the underlying state database. An iterator is returned
+	// which can be used to iterate (next) over the query result set.
+	// The query is NOT re-executed during validation phase, phantom reads are
+	// not detected, so it should be limited to read-only chaincode operations.
+	GetPrivateDataQueryResult(collection string, query string) (StateQueryIteratorInterface, error)
+
+	// SetStateValidationParameter sets the key-level endorsement policy for `key`.
+	SetStateValidationParameter(key string, ep []byte) error
+
+	// GetStateValidationParameter retrieves the key-level endorsement policy
+	// for `key`. Note that this will introduce a read dependency on `key` in
+	// the transaction's readset.
+	GetStateValidationParameter(key string) ([]byte, error)
+
+	// SetPrivateDataValidationParameter sets the key-level endorsement policy
+	// for the private data specified by `key`.
+	SetPrivateDataValidationParameter(collection string, key string, ep []byte) error
+
+	// GetPrivateDataValidationParameter retrieves the key-level endorsement
+	// policy for the private data specified by `key`. Note that this introduces
+	// a read dependency on `key` in the transaction's readset.
+	GetPrivateDataValidationParameter(collection string, key string) ([]byte, error)
+
+	// SetStateEndorsementPolicy sets the key-level endorsement policy for `key`
+	// from an EndorsementPolicy.
+	SetStateEndorsementPolicy(key string, policy *EndorsementPolicy) error
+
+	// GetStateEndorsementPolicy returns the key-level endorsement policy for `key`,
+	// or nil if none is set.
+	GetStateEndorsementPolicy(key string) (*EndorsementPolicy, error)
+
+	// SetPrivateDataEndorsementPolicy sets the key-level endorsement policy for
+	// the private data specified by `key` from an EndorsementPolicy.
+	SetPrivateDataEndorsementPolicy(collection string, key string, policy *EndorsementPolicy) error
+
+	// GetPrivateDataEndorsementPolicy returns the key-level endorsement policy for
+	// the private data specified by `key`, or nil if none is set.
+	GetPrivateDataEndorsementPolicy(collection string, key string) (*EndorsementPolicy, error)
+
 	// SetEvent allows the chaincode to set an event on the response to the
 	// proposal to be included as part of a transaction. The event will be
 	// available within the transaction in the committed block regardless of the
@@ -80,6 +197,10 @@ type TransactionContextInterface interface {
 	// transaction within the blockchain network.
 	GetTxID() string
 
+	// GetChannelName retrieves the name of the channel associated with the transaction context.
+	// It returns an error if the channel ID is empty.
+	GetChannelName() (string, error)
+
 	// GetChannelID returns the channel the proposal is sent to for chaincode to process.
 	// This would be the channel_id of the transaction proposal
 	GetChannelID() string
@@ -89,6 +210,25 @@ type TransactionContextInterface interface {
 	// reading or extracting the user ID.
 	GetUserID() (string, error)
 
+	// GetUserIdentity returns the identity of the client that submitted the transaction: the common name,
+	// organizational units, organization, MSP ID, subject and issuer of its X.509 certificate.
+	GetUserIdentity() (*UserIdentity, error)
+
+	// RequireAttribute checks that the client certificate has the attribute `name` with the value `value`,
+	// returning an *AccessDeniedError otherwise.
+	RequireAttribute(name string, value string) error
+
+	// RequireMSP checks that the client belongs to one of the given MSPs, returning an *AccessDeniedError otherwise.
+	RequireMSP(mspIDs ...string) error
+
+	// RequireAnyOf checks that the client satisfies at least one of the conditions, returning an
+	// *AccessDeniedError otherwise.
+	RequireAnyOf(conditions ...AccessCondition) error
+
+	// RequireKYCLevel checks that the submitting user has an active KYC of at least `minLevel`, returning an
+	// *AccessDeniedError otherwise.
+	RequireKYCLevel(minLevel KYCLevel) error
+
 	// InvokeChaincode locally calls the specified chaincode `Invoke` using the
 	// same transaction context. It allows one chaincode to invoke another chaincode
 	// within the same transaction. If the called chaincode is on the same channel as
@@ -162,6 +302,56 @@ type TransactionContextInterface interface {
 	// ledger, and should limit use to read-only chaincode operations.
 	GetQueryResult(query string) (StateQueryIteratorInterface, error)
 
+	// GetStateByRangeWithPagination returns a range iterator over a set of keys in the
+	// ledger. The iterator can be used to fetch keys between the startKey (inclusive)
+	// and endKey (exclusive).
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` keys between the startKey
+	// (inclusive) and endKey (exclusive).
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark (inclusive) and endKey (exclusive).
+	// Note that only the bookmark present in a prior page of query results (QueryResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string must
+	// be passed as bookmark.
+	// The keys are returned by the iterator in lexical order. Note
+	// that startKey and endKey can be empty string, which implies unbounded range
+	// query on start or end.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// This call is only supported in a read only transaction.
+	GetStateByRangeWithPagination(startKey string, endKey string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error)
+
+	// GetStateByPartialCompositeKeyWithPagination queries the state in the ledger based on
+	// a given partial composite key. This function returns an iterator
+	// which can be used to iterate over the composite keys whose
+	// prefix matches the given partial composite key.
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` composite keys whose prefix
+	// matches the given partial composite key.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark (inclusive) and the last matching
+	// composite key.
+	// Note that only the bookmark present in a prior page of query result (QueryResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string must
+	// be passed as bookmark.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// This call is only supported in a read only transaction.
+	GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error)
+
+	// GetQueryResultWithPagination performs a "rich" query against a state database.
+	// It is only supported for state databases that support rich query,
+	// e.g., CouchDB. The query string is in the native syntax
+	// of the underlying state database. An iterator is returned
+	// which can be used to iterate over keys in the query result set.
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` of query results.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark and the last key in the query result.
+	// Note that only the bookmark present in a prior page of query results (QueryResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string
+	// must be passed as bookmark.
+	// This call is only supported in a read only transaction.
+	GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (StateQueryIteratorInterface, *QueryResponseMetadata, error)
+
 	// GetHistoryForKey returns a history of key values across time.
 	// For each historic key update, the historic value and associated
 	// transaction id and timestamp are returned. The timestamp is the
@@ -192,6 +382,15 @@ type TransactionContextInterface interface {
 	// to be used as strings.
 	GetFunctionAndParameters() (string, []string)
 
+	// GetTransient returns the transient map of the transaction proposal. The transient map
+	// holds inputs that are passed to the chaincode but not recorded on the ledger, such as
+	// private data or encryption keys.
+	GetTransient() (map[string][]byte, error)
+
+	// GetPayment returns the payment of a payable contract transaction, parsed from the last argument of the
+	// function and validated before the function is called, or nil if the transaction carries no payment.
+	GetPayment() *PaymentTracker
+
 	// ValidateCreateTokenTransaction checks if the contract has been initialized, if the operator is authorized
 	// to create the token, and if the token with the given ID and document type is already minted. Returns an error
 	// if any of the checks fail, or nil if the transaction is valid.
@@ -212,11 +411,21 @@ type TransactionContextInterface interface {
 type TransactionContext struct {
 	stub           shim.ChaincodeStubInterface
 	clientIdentity cid.ClientIdentity
+	kycConfig      *KYCConfig
+	kycProvider    KYCProvider
+	kycCache       map[string]KYCStatus
+	kycCacheTxID   string
+	kycConfigCache *KYCConfig
+	kycConfigTxID  string
+	payment        *PaymentTracker
 }
 
 // SetStub stores the passed stub in the transaction context
 func (ctx *TransactionContext) SetStub(stub shim.ChaincodeStubInterface) {
 	ctx.stub = stub
+	ctx.kycCache = nil
+	ctx.kycConfigCache = nil
+	ctx.payment = nil
 }
 
 // SetClientIdentity stores the passed stub in the transaction context
@@ -0,0 +1,142 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+)
+
+// nonceKeyLabel derives the key used to compute the GCM nonces from the encryption key.
+const nonceKeyLabel = "kalpsdk-gcm-nonce"
+
+// GetTransient returns the transient map of the transaction proposal. The transient map holds inputs, such as
+// private data or keys, that are passed to the chaincode but are not recorded in the transaction on the ledger.
+//
+// Returns:
+//   - map[string][]byte: The transient map of the proposal.
+//   - error: An error if the transient map could not be read.
+func (ctx *TransactionContext) GetTransient() (map[string][]byte, error) {
+	return ctx.GetStub().GetTransient()
+}
+
+// getTransientValue returns the value of `key` in the transient map, or an error if it is missing.
+func getTransientValue(ctx TransactionContextInterface, key string) ([]byte, error) {
+	transient, err := ctx.GetTransient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transient map: %v", err)
+	}
+	value, ok := transient[key]
+	if !ok {
+		return nil, fmt.Errorf("transient key %s not found", key)
+	}
+	return value, nil
+}
+
+// ReadTransient decodes the JSON value of `key` in the transient map into a value of type T.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - key: The key of the value in the transient map.
+//
+// Returns:
+//   - T: The decoded value.
+//   - error: An error if the key is missing or its value could not be decoded into T.
+func ReadTransient[T any](ctx TransactionContextInterface, key string) (T, error) {
+	var value T
+	data, err := getTransientValue(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, fmt.Errorf("failed to unmarshal transient key %s: %v", key, err)
+	}
+	return value, nil
+}
+
+// EncryptWithTransientKey encrypts `plaintext` with AES-GCM, using the AES key passed in the transient map under
+// `transientKey`, so that a confidential value can be stored on the public ledger, e.g. with PutStateWithKYC.
+// The key must be 16, 24 or 32 bytes long.
+//
+// Every endorsing peer must produce the same ciphertext, so the nonce is derived from the key, the transaction ID
+// and the plaintext instead of being random. The nonce is prepended to the returned ciphertext.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - transientKey: The key of the AES key in the transient map.
+//   - plaintext: The value to encrypt.
+//
+// Returns:
+//   - []byte: The nonce followed by the sealed ciphertext.
+//   - error: An error if the key is missing or invalid.
+func EncryptWithTransientKey(ctx TransactionContextInterface, transientKey string, plaintext []byte) ([]byte, error) {
+	key, aead, err := transientAEAD(ctx, transientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := deriveNonce(key, ctx.GetTxID(), plaintext, aead.NonceSize())
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// DecryptWithTransientKey decrypts a value encrypted by EncryptWithTransientKey, using the AES key passed in the
+// transient map under `transientKey`.
+//
+// Parameters:
+//   - ctx: The transaction context.
+//   - transientKey: The key of the AES key in the transient map.
+//   - ciphertext: The value returned by EncryptWithTransientKey.
+//
+// Returns:
+//   - []byte: The decrypted value.
+//   - error: An error if the key is missing or invalid, or the ciphertext was not encrypted with it.
+func DecryptWithTransientKey(ctx TransactionContextInterface, transientKey string, ciphertext []byte) ([]byte, error) {
+	_, aead, err := transientAEAD(ctx, transientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %v", err)
+	}
+	return plaintext, nil
+}
+
+// transientAEAD returns the AES key stored in the transient map under `transientKey` and an AES-GCM cipher for it.
+func transientAEAD(ctx TransactionContextInterface, transientKey string) ([]byte, cipher.AEAD, error) {
+	key, err := getTransientValue(ctx, transientKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid encryption key in transient key %s: %v", transientKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, aead, nil
+}
+
+// deriveNonce computes a GCM nonce of `size` bytes as an HMAC-SHA256 over the transaction ID and the plaintext,
+// keyed with a key derived from the encryption key. A nonce only repeats if the same plaintext is encrypted twice
+// in the same transaction.
+func deriveNonce(key []byte, txID string, plaintext []byte, size int) []byte {
+	nonceKey := hmac.New(sha256.New, key)
+	nonceKey.Write([]byte(nonceKeyLabel))
+
+	mac := hmac.New(sha256.New, nonceKey.Sum(nil))
+	mac.Write([]byte(txID))
+	mac.Write([]byte{0})
+	mac.Write(plaintext)
+	return mac.Sum(nil)[:size]
+}
@@ -0,0 +1,98 @@
+package kalpsdk
+
+import (
+	//Standard Libs
+	"bytes"
+	"fmt"
+	"testing"
+
+	//Third party Libs
+	"github.com/p2eengineering/kalp-sdk-public/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTransient(t *testing.T) {
+	type paymentInput struct {
+		Reference string `json:"reference"`
+		Amount    int    `json:"amount"`
+	}
+
+	mockStub := new(mocks.ChaincodeStubInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetTransient").Return(map[string][]byte{
+		"payment": []byte(`{"reference":"ref-1","amount":100}`),
+		"broken":  []byte(`{`),
+	}, nil)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		payment, err := ReadTransient[paymentInput](tx, "payment")
+		require.NoError(t, err)
+		require.Equal(t, paymentInput{Reference: "ref-1", Amount: 100}, payment)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := ReadTransient[paymentInput](tx, "missing")
+		require.EqualError(t, err, "transient key missing not found")
+
+		_, err = ReadTransient[paymentInput](tx, "broken")
+		require.ErrorContains(t, err, "failed to unmarshal transient key broken")
+	})
+}
+
+func TestEncryptWithTransientKey(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	mockStub := new(mocks.ChaincodeStubInterface)
+	tx := &TransactionContext{
+		stub: mockStub,
+	}
+	mockStub.On("GetTransient").Return(map[string][]byte{"key": key, "otherKey": bytes.Repeat([]byte{8}, 16), "shortKey": []byte("short")}, nil)
+	mockStub.On("GetTxID").Return("tx1").Once()
+
+	ciphertext, err := EncryptWithTransientKey(tx, "key", []byte("kyc-hash"))
+	require.NoError(t, err)
+
+	// Check for success response
+	t.Run("Check for success response", func(t *testing.T) {
+		require.NotContains(t, string(ciphertext), "kyc-hash")
+		plaintext, err := DecryptWithTransientKey(tx, "key", ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, "kyc-hash", string(plaintext))
+	})
+
+	// Check that endorsing peers produce the same ciphertext, and other transactions a different one
+	t.Run("Check for deterministic encryption", func(t *testing.T) {
+		mockStub.On("GetTxID").Return("tx1").Once()
+		again, err := EncryptWithTransientKey(tx, "key", []byte("kyc-hash"))
+		require.NoError(t, err)
+		require.Equal(t, ciphertext, again)
+
+		mockStub.On("GetTxID").Return("tx2").Once()
+		other, err := EncryptWithTransientKey(tx, "key", []byte("kyc-hash"))
+		require.NoError(t, err)
+		require.NotEqual(t, ciphertext, other)
+	})
+
+	// Check for failure response
+	t.Run("Check for failure response", func(t *testing.T) {
+		_, err := DecryptWithTransientKey(tx, "otherKey", ciphertext)
+		require.ErrorContains(t, err, "failed to decrypt value")
+
+		_, err = DecryptWithTransientKey(tx, "key", ciphertext[:10])
+		require.EqualError(t, err, "ciphertext is too short")
+
+		_, err = EncryptWithTransientKey(tx, "shortKey", []byte("kyc-hash"))
+		require.ErrorContains(t, err, "invalid encryption key in transient key shortKey")
+
+		_, err = EncryptWithTransientKey(tx, "missing", []byte("kyc-hash"))
+		require.EqualError(t, err, "transient key missing not found")
+
+		failingStub := new(mocks.ChaincodeStubInterface)
+		failingStub.On("GetTransient").Return(nil, fmt.Errorf("no proposal"))
+		_, err = ReadTransient[string](&TransactionContext{stub: failingStub}, "key")
+		require.EqualError(t, err, "failed to get transient map: no proposal")
+	})
+}
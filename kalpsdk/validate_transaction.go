@@ -4,6 +4,9 @@ import (
 	//Standard Libs
 	"fmt"
 
+	//Custom Build Libs
+	"github.com/p2eengineering/kalp-sdk-public/kalpsdk/query"
+
 	//Third party Libs
 	"golang.org/x/exp/slices"
 )
@@ -45,12 +48,20 @@ func (ctx *TransactionContext) ValidateCreateTokenTransaction(id string, docType
 // IsMinted checks whether a token with the specified ID and document type is already minted or not.
 // Returns true if minted, false otherwise.
 func IsMinted(sdk *TransactionContext, id string, docType string) (bool, error) {
-	queryString := fmt.Sprintf(`{"selector": {"id": "%s", "docType": "%s"}}`, id, docType)
+	queryString, err := query.New(query.Selector{"id": id, "docType": docType}).Build()
+	if err != nil {
+		return false, err
+	}
 
 	resultsIterator, err := sdk.GetStub().GetQueryResult(queryString)
 	if err != nil {
 		return false, fmt.Errorf("failed to get query result from the world state: %v", err)
 	}
 
-	return resultsIterator.HasNext(), nil
+	// Count closes the iterator; one match is enough
+	count, err := Count(resultsIterator, 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to read query result from the world state: %v", err)
+	}
+	return count > 0, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/p2eengineering/kalp-sdk-public/mocks"
 
 	//Third party Libs
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,11 +24,12 @@ func TestValidateCreateTokenTransaction(t *testing.T) {
 
 	id := "sampleId"
 	docType := "ASSET-R2CI"
-	queryString := fmt.Sprintf(`{"selector": {"id": "%s", "docType": "%s"}}`, id, docType)
-	expectedId := "eDUwOTo6Q049VGVzdE93bmVyLDEyMw=="
+	queryString := `{"selector":{"docType":"ASSET-R2CI","id":"sampleId"}}`
+	expectedId := "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ=="
 
 	mockStub.On("GetQueryResult", queryString).Return(mockState, nil)
 	mockState.On("HasNext").Return(false).Once()
+	mockState.On("Close").Return(nil).Once()
 	mockClientIdentity.On("GetID").Return(expectedId, nil).Once()
 
 	// Check for success response
@@ -36,6 +38,7 @@ func TestValidateCreateTokenTransaction(t *testing.T) {
 		t.Errorf("Expected no error but Got:%v", err)
 	}
 	require.NoError(t, err)
+	mockState.AssertExpectations(t)
 
 }
 
@@ -49,16 +52,19 @@ func TestIsMinted(t *testing.T) {
 
 	id := "sampleId"
 	docType := "ASSET-R2CI"
-	queryString := fmt.Sprintf(`{"selector": {"id": "%s", "docType": "%s"}}`, id, docType)
+	queryString := `{"selector":{"docType":"ASSET-R2CI","id":"sampleId"}}`
 
 	// Check for success response
 	t.Run("Ckeck for success response", func(t *testing.T) {
 		mockStub.On("GetQueryResult", queryString).Return(mockState, nil)
 		mockState.On("HasNext").Return(true).Once()
+		mockState.On("Next").Return(&queryresult.KV{Key: id, Value: []byte(`{}`)}, nil).Once()
+		mockState.On("Close").Return(nil)
 		expectedbool := true
 		actualbool, err := IsMinted(ctx, id, docType)
 		require.NoError(t, err)
 		require.Equal(t, expectedbool, actualbool)
+		mockState.AssertCalled(t, "Close")
 	})
 
 	// Check for failure response
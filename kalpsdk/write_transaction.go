@@ -21,29 +21,29 @@ import (
 // Returns:
 //   - error: An error if the operation fails or if the user has not completed KYC.
 func (ctx *TransactionContext) PutStateWithKYC(key string, value []byte) error {
-	// Get the user ID
-	userID, err := ctx.GetUserID()
-	if err != nil {
-		return err
-	}
-
-	// Check if the user has completed KYC.
-	kycCheck, err := ctx.GetKYC(userID)
-	if err != nil {
-		return fmt.Errorf("failed to perform KYC check for user %s. Error: %v", userID, err)
-	}
-	// Return false if the user has not completed KYC.
-	if !kycCheck {
-		return fmt.Errorf("user %s has not completed KYC", userID)
-	}
+	return ctx.PutStateWithKYCLevel(key, value, KYCLevelBasic)
+}
 
-	// Put the state into the transaction's writeset.
-	err = ctx.GetStub().PutState(key, value)
-	if err != nil {
+// PutStateWithKYCLevel puts the specified `key` and `value` into the transaction's
+// writeset as a data-write proposal, only if the user has completed KYC of at least
+// `minLevel` according to the KYCProvider of the transaction, and the KYC is neither
+// revoked nor expired at the transaction timestamp.
+//
+// Parameters:
+//   - key: The key under which the data will be stored in the ledger.
+//   - value: The data to be stored in the ledger as a byte array.
+//   - minLevel: The minimum KYC level the user must have completed.
+//
+// Returns:
+//   - error: An error if the operation fails or if the user's KYC does not satisfy `minLevel`.
+func (ctx *TransactionContext) PutStateWithKYCLevel(key string, value []byte, minLevel KYCLevel) error {
+	// Check the KYC of the user.
+	if err := ctx.RequireKYCLevel(minLevel); err != nil {
 		return err
 	}
 
-	return nil
+	// Put the state into the transaction's writeset.
+	return ctx.GetStub().PutState(key, value)
 }
 
 // PutStateWithoutKYC puts the specified `key` and `value` into the transaction's
@@ -95,9 +95,10 @@ func (ctx *TransactionContext) InvokeChaincode(chaincodeName string, args [][]by
 }
 
 // PutKYC records the KYC information associated with a user.
-// It invokes the "kyc" chaincode with the specified parameters to create a KYC record.
-// This function should only be used by administrators to create KYC records. It invokes
-// the "kyc" chaincode with the specified parameters using the "universalkyc" chaincode name.
+// It invokes the create function of the KYC chaincode with the specified parameters to create a KYC record.
+// This function should only be used by administrators to create KYC records. The chaincode, channel and
+// function are taken from the KYCConfig of the transaction; by default the CreateKyc function of the kyc
+// chaincode on the current channel is invoked.
 //
 // Parameters:
 //   - id: The ID of the user.
@@ -107,20 +108,25 @@ func (ctx *TransactionContext) InvokeChaincode(chaincodeName string, args [][]by
 // Returns:
 //   - error: An error if the operation fails.
 func (ctx *TransactionContext) PutKYC(id string, kycId string, kycHash string) error {
+	config, err := ctx.GetKYCConfig()
+	if err != nil {
+		return err
+	}
+
 	// Prepare the parameters for the chaincode invocation
-	params := []string{"CreateKyc", id, kycId, kycHash}
+	params := []string{config.CreateFunction, id, kycId, kycHash}
 	invokeArgs := make([][]byte, len(params))
 	for i, arg := range params {
 		invokeArgs[i] = []byte(arg)
 	}
 
-	channelName, err := ctx.GetChannelName()
+	channelName, err := ctx.getKYCChannel(config)
 	if err != nil {
 		return fmt.Errorf("failed to get channel name: %s", err.Error())
 	}
 
-	// Invoke the "kyc" chaincode with the specified parameters using the "kyc" chaincode name
-	response := ctx.GetStub().InvokeChaincode("kyc", invokeArgs, channelName)
+	// Invoke the create function on the KYC chaincode
+	response := ctx.GetStub().InvokeChaincode(config.ChaincodeName, invokeArgs, channelName)
 
 	// Check the response status and return an error if it is not 200 (OK)
 	if response.Status != 200 {
@@ -163,28 +169,26 @@ func (ctx *TransactionContext) DelStateWithoutKYC(key string) error {
 // Returns:
 //   - error: An error if the deletion fails or if the user has not completed KYC.
 func (ctx *TransactionContext) DelStateWithKYC(key string) error {
-	// Get the user ID
-	userID, err := ctx.GetUserID()
-	if err != nil {
-		return err
-	}
-
-	// Check if the user has completed KYC.
-	kycCheck, err := ctx.GetKYC(userID)
-	if err != nil {
-		return fmt.Errorf("failed to perform KYC check for user %s. Error: %v", userID, err)
-	}
-
-	// Return an error if the user has not completed KYC.
-	if !kycCheck {
-		return fmt.Errorf("user %s has not completed KYC", userID)
-	}
+	return ctx.DelStateWithKYCLevel(key, KYCLevelBasic)
+}
 
-	// Delete the state from the world state.
-	err = ctx.GetStub().DelState(key)
-	if err != nil {
+// DelStateWithKYCLevel records the specified `key` to be deleted in the writeset of
+// the transaction proposal, only if the user has completed KYC of at least `minLevel`
+// according to the KYCProvider of the transaction, and the KYC is neither revoked nor
+// expired at the transaction timestamp.
+//
+// Parameters:
+//   - key: The key of the state to be deleted.
+//   - minLevel: The minimum KYC level the user must have completed.
+//
+// Returns:
+//   - error: An error if the deletion fails or if the user's KYC does not satisfy `minLevel`.
+func (ctx *TransactionContext) DelStateWithKYCLevel(key string, minLevel KYCLevel) error {
+	// Check the KYC of the user.
+	if err := ctx.RequireKYCLevel(minLevel); err != nil {
 		return err
 	}
 
-	return nil
+	// Delete the state from the world state.
+	return ctx.GetStub().DelState(key)
 }
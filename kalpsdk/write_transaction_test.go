@@ -102,6 +102,9 @@ func TestPutKYC(t *testing.T) {
 	tx := &TransactionContext{
 		stub: mockStub,
 	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Once()
+	mockStub.On("GetTxID").Return("tx1")
 
 	params := []string{"CreateKyc", "sampleId", "kycId", "kycHash"}
 	invokeArgs := make([][]byte, len(params))
@@ -137,13 +140,16 @@ func TestPutStateWithKYC(t *testing.T) {
 		stub:           mockStub,
 		clientIdentity: mockClientIdentity,
 	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Once()
+	mockStub.On("GetTxID").Return("tx1")
 
 	// Check for success response
 	t.Run("Check for success response", func(t *testing.T) {
 		expectedResponse := peer.Response{Status: shim.OK, Payload: []byte("true")}
-		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLDEyMw==", nil)
+		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==", nil)
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestOwner")}, "universalkyc").Return(expectedResponse)
-		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLDEyMw==").Return(false, nil)
+		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==").Return(false, nil)
 		mockStub.On("PutState", "key", []byte("value")).Return(nil).Once()
 
 		err := tx.PutStateWithKYC("key", []byte("value"))
@@ -154,9 +160,9 @@ func TestPutStateWithKYC(t *testing.T) {
 	// Check for failure response
 	t.Run("Check for failure response", func(t *testing.T) {
 		expectedResponse := peer.Response{Status: shim.ERROR, Payload: []byte("failed to query kyc chaincode")}
-		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLDEyMw==", nil)
+		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==", nil)
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestOwner")}, "universalkyc").Return(expectedResponse)
-		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLDEyMw==").Return(false, nil)
+		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==").Return(false, nil)
 		mockStub.On("PutState", "key", []byte("value")).Return(fmt.Errorf("failed to put key and value"))
 
 		//  failure response
@@ -173,13 +179,16 @@ func TestDelStateWithKYC(t *testing.T) {
 		stub:           mockStub,
 		clientIdentity: mockClientIdentity,
 	}
+	mockStub.On("GetChannelID").Return("universalkyc")
+	mockStub.On("GetState", "\x00KYC-CONFIG\x00").Return(nil, nil).Once()
+	mockStub.On("GetTxID").Return("tx1")
 
 	// Check for success response
 	t.Run("Check for success response", func(t *testing.T) {
 		expectedResponse := peer.Response{Status: shim.OK, Payload: []byte("true")}
-		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLDEyMw==", nil)
+		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==", nil)
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestOwner")}, "universalkyc").Return(expectedResponse)
-		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLDEyMw==").Return(false, nil)
+		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==").Return(false, nil)
 		mockStub.On("DelState", "key").Return(nil).Once()
 
 		err := tx.DelStateWithKYC("key")
@@ -190,9 +199,9 @@ func TestDelStateWithKYC(t *testing.T) {
 	// Check for failure response
 	t.Run("Check for failure response", func(t *testing.T) {
 		expectedResponse := peer.Response{Status: shim.ERROR, Payload: []byte("failed to query kyc chaincode")}
-		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLDEyMw==", nil)
+		mockClientIdentity.On("GetID").Return("eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==", nil)
 		mockStub.On("InvokeChaincode", "kyc", [][]byte{[]byte("KycExists"), []byte("TestOwner")}, "universalkyc").Return(expectedResponse)
-		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLDEyMw==").Return(false, nil)
+		mockStub.On("GetKYC", "eDUwOTo6Q049VGVzdE93bmVyLE9VPWNsaWVudDo6Q049Y2Eub3JnMS5leGFtcGxlLmNvbQ==").Return(false, nil)
 		mockStub.On("DelState", "key").Return(fmt.Errorf("failed to Delete the state from the world state. "))
 
 		err := tx.DelStateWithKYC("key")